@@ -0,0 +1,269 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Hyperparameters controls an OpenAI-compatible supervised fine-tuning run.
+type Hyperparameters struct {
+	NEpochs                any `json:"n_epochs,omitempty"`
+	BatchSize              any `json:"batch_size,omitempty"`
+	LearningRateMultiplier any `json:"learning_rate_multiplier,omitempty"`
+}
+
+// FineTuningJobRequest is the body of a POST /v1/fine_tuning/jobs request.
+type FineTuningJobRequest struct {
+	Model           string           `json:"model"`
+	TrainingFile    string           `json:"training_file"`
+	ValidationFile  string           `json:"validation_file,omitempty"`
+	Hyperparameters *Hyperparameters `json:"hyperparameters,omitempty"`
+	Suffix          string           `json:"suffix,omitempty"`
+}
+
+// FineTuningJobError describes why a fine-tuning job failed.
+type FineTuningJobError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// FineTuningJob is an OpenAI-compatible fine-tuning job resource.
+type FineTuningJob struct {
+	ID              string              `json:"id"`
+	Object          string              `json:"object"`
+	Model           string              `json:"model"`
+	CreatedAt       int64               `json:"created_at"`
+	FinishedAt      *int64              `json:"finished_at,omitempty"`
+	Status          string              `json:"status"`
+	FineTunedModel  string              `json:"fine_tuned_model,omitempty"`
+	TrainingFile    string              `json:"training_file"`
+	ValidationFile  string              `json:"validation_file,omitempty"`
+	Hyperparameters *Hyperparameters    `json:"hyperparameters,omitempty"`
+	ResultFiles     []string            `json:"result_files,omitempty"`
+	Error           *FineTuningJobError `json:"error,omitempty"`
+}
+
+// FineTuningJobEvent is a single status/log entry for a fine-tuning job.
+type FineTuningJobEvent struct {
+	ID        string `json:"id"`
+	CreatedAt int64  `json:"created_at"`
+	Level     string `json:"level"`
+	Message   string `json:"message"`
+}
+
+type fineTuningJobList struct {
+	Data    []FineTuningJob `json:"data"`
+	HasMore bool            `json:"has_more"`
+}
+
+type fineTuningJobEventList struct {
+	Data    []FineTuningJobEvent `json:"data"`
+	HasMore bool                 `json:"has_more"`
+}
+
+// FileObject is an OpenAI-compatible uploaded file resource, as used for
+// fine-tuning training and validation data.
+type FileObject struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	Bytes     int64  `json:"bytes"`
+	CreatedAt int64  `json:"created_at"`
+	Filename  string `json:"filename"`
+	Purpose   string `json:"purpose"`
+}
+
+type fileList struct {
+	Data []FileObject `json:"data"`
+}
+
+// CreateFineTuningJob submits a new supervised fine-tuning job.
+func (c *RemoteClient) CreateFineTuningJob(ctx context.Context, req *FineTuningJobRequest) (*FineTuningJob, error) {
+	var job FineTuningJob
+	if err := c.doJSON(ctx, "POST", "/v1/fine_tuning/jobs", nil, req, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// RetrieveFineTuningJob fetches the current state of a fine-tuning job.
+func (c *RemoteClient) RetrieveFineTuningJob(ctx context.Context, jobID string) (*FineTuningJob, error) {
+	var job FineTuningJob
+	if err := c.doJSON(ctx, "GET", "/v1/fine_tuning/jobs/"+url.PathEscape(jobID), nil, nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CancelFineTuningJob requests cancellation of an in-progress fine-tuning job.
+func (c *RemoteClient) CancelFineTuningJob(ctx context.Context, jobID string) (*FineTuningJob, error) {
+	var job FineTuningJob
+	if err := c.doJSON(ctx, "POST", "/v1/fine_tuning/jobs/"+url.PathEscape(jobID)+"/cancel", nil, nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ListFineTuningJobs pages through fine-tuning jobs, newest first.
+func (c *RemoteClient) ListFineTuningJobs(ctx context.Context, after string, limit int) ([]FineTuningJob, bool, error) {
+	query := url.Values{}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var list fineTuningJobList
+	if err := c.doJSON(ctx, "GET", "/v1/fine_tuning/jobs", query, nil, &list); err != nil {
+		return nil, false, err
+	}
+	return list.Data, list.HasMore, nil
+}
+
+// ListFineTuningJobEvents pages through a fine-tuning job's status/log events.
+func (c *RemoteClient) ListFineTuningJobEvents(ctx context.Context, jobID, after string, limit int) ([]FineTuningJobEvent, bool, error) {
+	query := url.Values{}
+	if after != "" {
+		query.Set("after", after)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var list fineTuningJobEventList
+	if err := c.doJSON(ctx, "GET", "/v1/fine_tuning/jobs/"+url.PathEscape(jobID)+"/events", query, nil, &list); err != nil {
+		return nil, false, err
+	}
+	return list.Data, list.HasMore, nil
+}
+
+// UploadFile multipart-uploads training or validation data for the given
+// purpose (typically "fine-tune") to /v1/files.
+func (c *RemoteClient) UploadFile(ctx context.Context, filename, purpose string, data []byte) (*FileObject, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fw, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := fw.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to write file data: %w", err)
+	}
+	if err := writer.WriteField("purpose", purpose); err != nil {
+		return nil, fmt.Errorf("failed to write purpose field: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	requestURL := *c.base
+	requestURL.Path = "/v1/files"
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), &buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(b))
+	}
+
+	var file FileObject
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return &file, nil
+}
+
+// ListFiles lists uploaded files, optionally filtered by purpose.
+func (c *RemoteClient) ListFiles(ctx context.Context, purpose string) ([]FileObject, error) {
+	var query url.Values
+	if purpose != "" {
+		query = url.Values{"purpose": []string{purpose}}
+	}
+
+	var list fileList
+	if err := c.doJSON(ctx, "GET", "/v1/files", query, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Data, nil
+}
+
+// DeleteFile deletes a previously uploaded file.
+func (c *RemoteClient) DeleteFile(ctx context.Context, fileID string) error {
+	return c.doJSON(ctx, "DELETE", "/v1/files/"+url.PathEscape(fileID), nil, nil, nil)
+}
+
+// doJSON sends a JSON request to path (already escaped, optionally with
+// query params and a request body) and decodes the JSON response into out,
+// if non-nil.
+func (c *RemoteClient) doJSON(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	unescaped, err := url.PathUnescape(path)
+	if err != nil {
+		return fmt.Errorf("invalid request path: %w", err)
+	}
+	requestURL := *c.base
+	requestURL.Path = unescaped
+	requestURL.RawPath = path
+	if query != nil {
+		requestURL.RawQuery = query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, requestURL.String(), reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(b))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	return nil
+}
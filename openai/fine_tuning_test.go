@@ -0,0 +1,129 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func newTestRemoteClient(t *testing.T, handler http.HandlerFunc) *RemoteClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	base, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("failed to parse test server URL: %v", err)
+	}
+	return NewRemoteClient(base, "test-key", srv.Client())
+}
+
+func TestCreateFineTuningJob(t *testing.T) {
+	client := newTestRemoteClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/fine_tuning/jobs" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("unexpected Authorization header: %q", got)
+		}
+
+		var req FineTuningJobRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if req.Model != "gpt-4o-mini" || req.TrainingFile != "file-abc" {
+			t.Errorf("unexpected request body: %+v", req)
+		}
+
+		json.NewEncoder(w).Encode(FineTuningJob{ID: "ftjob-1", Status: "queued"})
+	})
+
+	job, err := client.CreateFineTuningJob(context.Background(), &FineTuningJobRequest{Model: "gpt-4o-mini", TrainingFile: "file-abc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.ID != "ftjob-1" || job.Status != "queued" {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+}
+
+func TestRetrieveFineTuningJobPathEscapesID(t *testing.T) {
+	client := newTestRemoteClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/v1/fine_tuning/jobs/ftjob%2Fwith-slash" {
+			t.Errorf("unexpected path: %s", r.URL.EscapedPath())
+		}
+		json.NewEncoder(w).Encode(FineTuningJob{ID: "ftjob/with-slash"})
+	})
+
+	job, err := client.RetrieveFineTuningJob(context.Background(), "ftjob/with-slash")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.ID != "ftjob/with-slash" {
+		t.Fatalf("unexpected job: %+v", job)
+	}
+}
+
+func TestListFineTuningJobsSendsPaginationParams(t *testing.T) {
+	client := newTestRemoteClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("after"); got != "ftjob-1" {
+			t.Errorf("unexpected after param: %q", got)
+		}
+		if got := r.URL.Query().Get("limit"); got != "5" {
+			t.Errorf("unexpected limit param: %q", got)
+		}
+		json.NewEncoder(w).Encode(fineTuningJobList{Data: []FineTuningJob{{ID: "ftjob-2"}}, HasMore: true})
+	})
+
+	jobs, hasMore, err := client.ListFineTuningJobs(context.Background(), "ftjob-1", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !hasMore || len(jobs) != 1 || jobs[0].ID != "ftjob-2" {
+		t.Fatalf("unexpected result: jobs=%+v hasMore=%v", jobs, hasMore)
+	}
+}
+
+func TestUploadFileSendsMultipartForm(t *testing.T) {
+	client := newTestRemoteClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("failed to parse multipart form: %v", err)
+		}
+		if got := r.FormValue("purpose"); got != "fine-tune" {
+			t.Errorf("unexpected purpose field: %q", got)
+		}
+		f, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("failed to read form file: %v", err)
+		}
+		defer f.Close()
+		if header.Filename != "train.jsonl" {
+			t.Errorf("unexpected filename: %q", header.Filename)
+		}
+
+		json.NewEncoder(w).Encode(FileObject{ID: "file-xyz", Filename: "train.jsonl", Purpose: "fine-tune"})
+	})
+
+	file, err := client.UploadFile(context.Background(), "train.jsonl", "fine-tune", []byte(`{"prompt":"hi"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.ID != "file-xyz" {
+		t.Fatalf("unexpected file: %+v", file)
+	}
+}
+
+func TestDeleteFileSurfacesAPIErrors(t *testing.T) {
+	client := newTestRemoteClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not found"}`))
+	})
+
+	err := client.DeleteFile(context.Background(), "file-missing")
+	if err == nil {
+		t.Fatal("expected an error for a 404 response")
+	}
+}
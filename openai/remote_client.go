@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/ollama/ollama/api"
@@ -21,10 +23,22 @@ type RemoteClient struct {
 	apiKey string
 }
 
-// NewRemoteClient creates a new OpenAI-compatible client
+// NewRemoteClient creates a new OpenAI-compatible client. If httpClient is
+// nil, or its Transport isn't already a remoteHTTPDoer, the client's
+// Transport is wrapped with the shared retry, rate-limit, and
+// circuit-breaker behavior - reusing the process-wide sharedDoer when no
+// custom Transport was supplied, so that state isn't lost across calls.
 func NewRemoteClient(base *url.URL, apiKey string, httpClient *http.Client) *RemoteClient {
 	if httpClient == nil {
-		httpClient = http.DefaultClient
+		httpClient = &http.Client{}
+	}
+	if _, ok := httpClient.Transport.(*remoteHTTPDoer); !ok {
+		httpClient = &http.Client{
+			Transport:     remoteHTTPDoerFor(httpClient.Transport),
+			Timeout:       httpClient.Timeout,
+			CheckRedirect: httpClient.CheckRedirect,
+			Jar:           httpClient.Jar,
+		}
 	}
 	return &RemoteClient{
 		base:   base,
@@ -180,8 +194,66 @@ func (c *RemoteClient) handleNonStreamingResponse(resp *http.Response, fn func(a
 	return fn(chatResp)
 }
 
+// toolCallAccumulator buffers a single tool call's streamed Function.Arguments
+// fragments. OpenAI-compatible providers stream arguments as raw JSON string
+// pieces (e.g. `{"loc`, `ation":"S`, `F"}`) rather than whole values, so the
+// fragments must be concatenated before they can be unmarshaled.
+type toolCallAccumulator struct {
+	id        string
+	name      string
+	arguments strings.Builder
+}
+
+// accumulateToolCalls merges a chunk's tool-call deltas into accs, growing it
+// as new indices are seen and applying set-once semantics to ID and Name.
+func accumulateToolCalls(accs *[]*toolCallAccumulator, chunk *ChatCompletionChunk) {
+	if len(chunk.Choices) == 0 {
+		return
+	}
+
+	for _, tc := range chunk.Choices[0].Delta.ToolCalls {
+		for len(*accs) <= tc.Index {
+			*accs = append(*accs, &toolCallAccumulator{})
+		}
+
+		acc := (*accs)[tc.Index]
+		if tc.ID != "" && acc.id == "" {
+			acc.id = tc.ID
+		}
+		if tc.Function.Name != "" && acc.name == "" {
+			acc.name = tc.Function.Name
+		}
+		acc.arguments.WriteString(tc.Function.Arguments)
+	}
+}
+
+// finalizeToolCalls unmarshals each accumulator's fully assembled arguments
+// string. It is only safe to call once the stream has signalled completion.
+func finalizeToolCalls(accs []*toolCallAccumulator) []api.ToolCall {
+	var toolCalls []api.ToolCall
+	for _, acc := range accs {
+		var args api.ToolCallFunctionArguments
+		if acc.arguments.Len() > 0 {
+			if err := json.Unmarshal([]byte(acc.arguments.String()), &args); err != nil {
+				continue
+			}
+		}
+		toolCalls = append(toolCalls, api.ToolCall{
+			ID: acc.id,
+			Function: api.ToolCallFunction{
+				Name:      acc.name,
+				Arguments: args,
+			},
+		})
+	}
+	return toolCalls
+}
+
 func (c *RemoteClient) handleStreamingResponse(resp *http.Response, fn func(api.ChatResponse) error, model string) error {
 	scanner := bufio.NewScanner(resp.Body)
+	var toolCallAccs []*toolCallAccumulator
+	toolCallsSent := false
+
 	for scanner.Scan() {
 		line := scanner.Text()
 
@@ -209,8 +281,15 @@ func (c *RemoteClient) handleStreamingResponse(resp *http.Response, fn func(api.
 			continue // Skip malformed chunks
 		}
 
+		// Buffer tool-call argument fragments; they are only unmarshaled
+		// once the choice's finish_reason confirms the call is complete.
+		accumulateToolCalls(&toolCallAccs, &chunk)
+
 		// Convert to Ollama format
-		chatResp := c.convertFromOpenAIChunk(&chunk, model)
+		chatResp := c.convertFromOpenAIChunk(&chunk, model, toolCallAccs)
+		if len(chunk.Choices) > 0 && chunk.Choices[0].FinishReason != nil && *chunk.Choices[0].FinishReason == "tool_calls" {
+			toolCallsSent = true
+		}
 		if err := fn(chatResp); err != nil {
 			return err
 		}
@@ -220,6 +299,19 @@ func (c *RemoteClient) handleStreamingResponse(resp *http.Response, fn func(api.
 		return fmt.Errorf("error reading stream: %w", err)
 	}
 
+	// Some providers end the stream (e.g. on early disconnect) without ever
+	// sending a finish_reason of "tool_calls". Flush whatever was buffered
+	// so a completed tool call is never silently dropped.
+	if !toolCallsSent && len(toolCallAccs) > 0 {
+		return fn(api.ChatResponse{
+			Model:      model,
+			CreatedAt:  time.Now(),
+			Message:    api.Message{Role: "assistant", ToolCalls: finalizeToolCalls(toolCallAccs)},
+			Done:       true,
+			DoneReason: "tool_calls",
+		})
+	}
+
 	return nil
 }
 
@@ -263,28 +355,13 @@ func (c *RemoteClient) convertFromOpenAIResponse(resp *ChatCompletion, model str
 	}
 }
 
-func (c *RemoteClient) convertFromOpenAIChunk(chunk *ChatCompletionChunk, model string) api.ChatResponse {
+func (c *RemoteClient) convertFromOpenAIChunk(chunk *ChatCompletionChunk, model string, toolCallAccs []*toolCallAccumulator) api.ChatResponse {
 	var msg api.Message
 	if len(chunk.Choices) > 0 {
 		choice := chunk.Choices[0]
 		msg.Role = choice.Delta.Role
-		msg.Content = choice.Delta.Content.(string)
-
-		// Convert tool calls
-		if len(choice.Delta.ToolCalls) > 0 {
-			for _, tc := range choice.Delta.ToolCalls {
-				var args api.ToolCallFunctionArguments
-				if tc.Function.Arguments != "" {
-					json.Unmarshal([]byte(tc.Function.Arguments), &args)
-				}
-				msg.ToolCalls = append(msg.ToolCalls, api.ToolCall{
-					ID: tc.ID,
-					Function: api.ToolCallFunction{
-						Name:      tc.Function.Name,
-						Arguments: args,
-					},
-				})
-			}
+		if content, ok := choice.Delta.Content.(string); ok {
+			msg.Content = content
 		}
 
 		// Handle reasoning/thinking if present
@@ -298,6 +375,12 @@ func (c *RemoteClient) convertFromOpenAIChunk(chunk *ChatCompletionChunk, model
 	if len(chunk.Choices) > 0 && chunk.Choices[0].FinishReason != nil {
 		done = true
 		doneReason = *chunk.Choices[0].FinishReason
+
+		// Tool call arguments are only fully assembled once the provider
+		// signals completion, so emit them here rather than per-fragment.
+		if doneReason == "tool_calls" {
+			msg.ToolCalls = finalizeToolCalls(toolCallAccs)
+		}
 	}
 
 	resp := api.ChatResponse{
@@ -319,3 +402,228 @@ func (c *RemoteClient) convertFromOpenAIChunk(chunk *ChatCompletionChunk, model
 
 	return resp
 }
+
+// Embeddings sends an embeddings request to an OpenAI-compatible API and
+// converts the response back to Ollama's EmbedResponse format
+func (c *RemoteClient) Embeddings(ctx context.Context, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+	openaiReq := &EmbeddingsRequest{
+		Model:          req.Model,
+		Input:          req.Input,
+		Dimensions:     req.Dimensions,
+		EncodingFormat: "float",
+	}
+
+	requestURL := *c.base
+	requestURL.Path = "/v1/embeddings"
+
+	body, err := json.Marshal(openaiReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var openaiResp EmbeddingsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&openaiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	embeddings := make([][]float32, len(openaiResp.Data))
+	for _, d := range openaiResp.Data {
+		if d.Index >= 0 && d.Index < len(embeddings) {
+			embeddings[d.Index] = d.Embedding
+		}
+	}
+
+	return &api.EmbedResponse{
+		Model:           req.Model,
+		Embeddings:      embeddings,
+		PromptEvalCount: openaiResp.Usage.PromptTokens,
+	}, nil
+}
+
+// Transcribe multipart-uploads an audio file to an OpenAI-compatible
+// Whisper-style transcription endpoint and converts the response back to
+// Ollama's TranscribeResponse format
+func (c *RemoteClient) Transcribe(ctx context.Context, req *api.TranscribeRequest) (*api.TranscribeResponse, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	fileWriter, err := writer.CreateFormFile("file", req.Filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %w", err)
+	}
+	if _, err := fileWriter.Write(req.Audio); err != nil {
+		return nil, fmt.Errorf("failed to write audio data: %w", err)
+	}
+
+	if err := writer.WriteField("model", req.Model); err != nil {
+		return nil, fmt.Errorf("failed to write model field: %w", err)
+	}
+	if req.Language != "" {
+		if err := writer.WriteField("language", req.Language); err != nil {
+			return nil, fmt.Errorf("failed to write language field: %w", err)
+		}
+	}
+
+	responseFormat := req.ResponseFormat
+	if responseFormat == "" {
+		responseFormat = "json"
+	}
+	// Whisper-style endpoints only populate segment/word timestamps on a
+	// verbose_json response, so a caller who asked for Timestamps but didn't
+	// pick a format themselves would otherwise get back an empty
+	// Segments/Words despite timestamp_granularities[] being sent.
+	if req.Timestamps && responseFormat != "verbose_json" {
+		responseFormat = "verbose_json"
+	}
+	if err := writer.WriteField("response_format", responseFormat); err != nil {
+		return nil, fmt.Errorf("failed to write response_format field: %w", err)
+	}
+	if req.Temperature != nil {
+		if err := writer.WriteField("temperature", fmt.Sprintf("%g", *req.Temperature)); err != nil {
+			return nil, fmt.Errorf("failed to write temperature field: %w", err)
+		}
+	}
+	if req.Timestamps {
+		if err := writer.WriteField("timestamp_granularities[]", "segment"); err != nil {
+			return nil, fmt.Errorf("failed to write timestamp_granularities field: %w", err)
+		}
+		if err := writer.WriteField("timestamp_granularities[]", "word"); err != nil {
+			return nil, fmt.Errorf("failed to write timestamp_granularities field: %w", err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+
+	requestURL := *c.base
+	requestURL.Path = "/v1/audio/transcriptions"
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), &buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var openaiResp TranscriptionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&openaiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	transcribeResp := &api.TranscribeResponse{
+		Text:     openaiResp.Text,
+		Language: openaiResp.Language,
+		Duration: openaiResp.Duration,
+	}
+	for _, seg := range openaiResp.Segments {
+		transcribeResp.Segments = append(transcribeResp.Segments, api.TranscribeSegment{
+			Start: seg.Start,
+			End:   seg.End,
+			Text:  seg.Text,
+		})
+	}
+	for _, w := range openaiResp.Words {
+		transcribeResp.Words = append(transcribeResp.Words, api.TranscribeWord{
+			Start: w.Start,
+			End:   w.End,
+			Word:  w.Word,
+		})
+	}
+
+	return transcribeResp, nil
+}
+
+// GenerateImage sends an image generation request to an OpenAI-compatible
+// API and converts the response back to Ollama's ImageResponse format
+func (c *RemoteClient) GenerateImage(ctx context.Context, req *api.ImageRequest) (*api.ImageResponse, error) {
+	openaiReq := &ImageGenerationRequest{
+		Model:          req.Model,
+		Prompt:         req.Prompt,
+		N:              req.N,
+		Size:           req.Size,
+		ResponseFormat: req.ResponseFormat,
+	}
+
+	requestURL := *c.base
+	requestURL.Path = "/v1/images/generations"
+
+	body, err := json.Marshal(openaiReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.apiKey))
+	}
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	var openaiResp ImageGenerationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&openaiResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	imgResp := &api.ImageResponse{
+		CreatedAt: time.Unix(openaiResp.Created, 0),
+	}
+	for _, d := range openaiResp.Data {
+		imgResp.Images = append(imgResp.Images, api.GeneratedImage{
+			URL:           d.URL,
+			Base64:        d.B64JSON,
+			RevisedPrompt: d.RevisedPrompt,
+		})
+	}
+
+	return imgResp, nil
+}
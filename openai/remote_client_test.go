@@ -0,0 +1,76 @@
+package openai
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// mustChunk unmarshals a raw OpenAI-style streaming chunk payload, the same
+// wire format handleStreamingResponse decodes one SSE "data: " line at a
+// time.
+func mustChunk(t *testing.T, raw string) *ChatCompletionChunk {
+	t.Helper()
+	var chunk ChatCompletionChunk
+	if err := json.Unmarshal([]byte(raw), &chunk); err != nil {
+		t.Fatalf("failed to unmarshal chunk fixture: %v", err)
+	}
+	return &chunk
+}
+
+// TestAccumulateToolCallsSplitFragments verifies that a tool call's
+// Function.Arguments, streamed across several chunks as raw JSON string
+// fragments (as real providers do, splitting mid-token), is reassembled
+// correctly before being unmarshaled.
+func TestAccumulateToolCallsSplitFragments(t *testing.T) {
+	fragments := []string{
+		`{"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "id": "call_1", "function": {"name": "get_weather", "arguments": "{\"loc"}}]}}]}`,
+		`{"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "ation\":\"S"}}]}}]}`,
+		`{"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "F\"}"}}]}, "finish_reason": "tool_calls"}]}`,
+	}
+
+	var accs []*toolCallAccumulator
+	for _, raw := range fragments {
+		accumulateToolCalls(&accs, mustChunk(t, raw))
+	}
+
+	toolCalls := finalizeToolCalls(accs)
+	if len(toolCalls) != 1 {
+		t.Fatalf("expected 1 tool call, got %d", len(toolCalls))
+	}
+
+	tc := toolCalls[0]
+	if tc.ID != "call_1" || tc.Function.Name != "get_weather" {
+		t.Fatalf("unexpected tool call identity: %+v", tc)
+	}
+	if got := tc.Function.Arguments["location"]; got != "SF" {
+		t.Fatalf("expected location %q, got %q", "SF", got)
+	}
+}
+
+// TestAccumulateToolCallsInterleaved verifies that fragments for two
+// concurrently streamed tool calls, interleaved by index rather than
+// arriving one call at a time, don't get cross-contaminated.
+func TestAccumulateToolCallsInterleaved(t *testing.T) {
+	fragments := []string{
+		`{"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "id": "call_1", "function": {"name": "a", "arguments": "{\"x"}}]}}]}`,
+		`{"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 1, "id": "call_2", "function": {"name": "b", "arguments": "{\"y"}}]}}]}`,
+		`{"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "\":1}"}}]}}]}`,
+		`{"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 1, "function": {"arguments": "\":2}"}}]}, "finish_reason": "tool_calls"}]}`,
+	}
+
+	var accs []*toolCallAccumulator
+	for _, raw := range fragments {
+		accumulateToolCalls(&accs, mustChunk(t, raw))
+	}
+
+	toolCalls := finalizeToolCalls(accs)
+	if len(toolCalls) != 2 {
+		t.Fatalf("expected 2 tool calls, got %d", len(toolCalls))
+	}
+	if toolCalls[0].Function.Name != "a" || toolCalls[0].Function.Arguments["x"] != float64(1) {
+		t.Fatalf("unexpected first tool call: %+v", toolCalls[0])
+	}
+	if toolCalls[1].Function.Name != "b" || toolCalls[1].Function.Arguments["y"] != float64(2) {
+		t.Fatalf("unexpected second tool call: %+v", toolCalls[1])
+	}
+}
@@ -0,0 +1,363 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxRetries       = 3
+	defaultMaxBackoff       = 30 * time.Second
+	circuitBreakerThreshold = 5
+	circuitBreakerCooldown  = 30 * time.Second
+)
+
+// retryableStatus lists the HTTP statuses remoteHTTPDoer treats as
+// transient and worth retrying.
+var retryableStatus = map[int]bool{
+	http.StatusRequestTimeout:      true, // 408
+	http.StatusTooEarly:            true, // 425
+	http.StatusTooManyRequests:     true, // 429
+	http.StatusInternalServerError: true, // 500
+	http.StatusBadGateway:          true, // 502
+	http.StatusServiceUnavailable:  true, // 503
+	http.StatusGatewayTimeout:      true, // 504
+}
+
+// remoteHTTPDoer wraps an http.RoundTripper with exponential backoff and
+// full jitter for retryable statuses and network errors, honors
+// Retry-After and OpenAI-style rate-limit headers, enforces a per-host
+// token-bucket rate limit, and trips a per-host circuit breaker after
+// repeated failures. It is shared by RemoteClient and server's
+// callOpenAICompatibleAPI so both get the same resilience behavior.
+//
+// Because retries happen inside RoundTrip, before any response is ever
+// returned to a caller, a streaming request is only ever retried before the
+// first byte of the body reaches the caller's callback; once RoundTrip
+// returns, errors from reading the body propagate as-is.
+type remoteHTTPDoer struct {
+	next       http.RoundTripper
+	maxRetries int
+	maxBackoff time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+	limiters map[string]*tokenBucket
+}
+
+// newRemoteHTTPDoer wraps next (http.DefaultTransport if nil). Retry limits
+// can be tuned via OLLAMA_REMOTE_MAX_RETRIES and OLLAMA_REMOTE_MAX_BACKOFF.
+func newRemoteHTTPDoer(next http.RoundTripper) *remoteHTTPDoer {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &remoteHTTPDoer{
+		next:       next,
+		maxRetries: envInt("OLLAMA_REMOTE_MAX_RETRIES", defaultMaxRetries),
+		maxBackoff: envDuration("OLLAMA_REMOTE_MAX_BACKOFF", defaultMaxBackoff),
+		breakers:   make(map[string]*circuitBreaker),
+		limiters:   make(map[string]*tokenBucket),
+	}
+}
+
+// sharedDoer is the process-wide remoteHTTPDoer behind every
+// NewRemoteHTTPClient. Its breakers and limiters are keyed by host, so one
+// instance already behaves as a per-host cache; what it must not do is get
+// rebuilt on every call, which would silently drop all breaker/limiter state
+// between requests. sharedDoerOnce makes sure it's built exactly once.
+var (
+	sharedDoerOnce sync.Once
+	sharedDoer     *remoteHTTPDoer
+)
+
+// NewRemoteHTTPClient returns an *http.Client with the shared retry,
+// rate-limit, and circuit-breaker behavior applied, for callers that build
+// their own request rather than going through RemoteClient. The returned
+// client's Transport is the process-wide sharedDoer, so breaker and
+// rate-limiter state persists across calls instead of resetting each time.
+func NewRemoteHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Transport: remoteHTTPDoerFor(nil),
+		Timeout:   timeout,
+	}
+}
+
+// remoteHTTPDoerFor returns the doer to wrap next with. When next is nil -
+// the common case, meaning the caller wants the default resilience behavior
+// over http.DefaultTransport - it returns the process-wide sharedDoer so
+// breaker and rate-limiter state accumulates across calls instead of
+// resetting every time. A non-nil next is assumed to be a caller-supplied
+// custom RoundTripper, which gets its own doer rather than sharing state
+// meant for the default transport.
+func remoteHTTPDoerFor(next http.RoundTripper) *remoteHTTPDoer {
+	if next == nil {
+		sharedDoerOnce.Do(func() {
+			sharedDoer = newRemoteHTTPDoer(nil)
+		})
+		return sharedDoer
+	}
+	return newRemoteHTTPDoer(next)
+}
+
+func (d *remoteHTTPDoer) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Host
+
+	if !d.breaker(host).allow() {
+		return nil, fmt.Errorf("circuit breaker open for %s", host)
+	}
+
+	if lim := d.limiter(host); lim != nil {
+		lim.wait(req.Context())
+	}
+
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		bodyBytes, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt <= d.maxRetries; attempt++ {
+		if bodyBytes != nil {
+			req.Body = io.NopCloser(bytes.NewReader(bodyBytes))
+			req.ContentLength = int64(len(bodyBytes))
+		}
+
+		resp, err = d.next.RoundTrip(req)
+		if err == nil {
+			d.updateLimiter(host, resp.Header)
+		}
+
+		if err == nil && !retryableStatus[resp.StatusCode] {
+			d.breaker(host).recordSuccess()
+			return resp, nil
+		}
+
+		d.breaker(host).recordFailure()
+
+		if attempt == d.maxRetries {
+			break
+		}
+
+		wait := retryDelay(resp, attempt, d.maxBackoff)
+		slog.Warn("retrying remote request", "host", host, "attempt", attempt+1, "wait", wait, "error", err)
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+
+	return resp, err
+}
+
+// retryDelay picks the wait before the next attempt: a provider-supplied
+// Retry-After or rate-limit reset header takes priority over exponential
+// backoff with full jitter.
+func retryDelay(resp *http.Response, attempt int, maxBackoff time.Duration) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			return capDuration(d, maxBackoff)
+		}
+		if d, ok := parseRateLimitReset(resp.Header); ok {
+			return capDuration(d, maxBackoff)
+		}
+	}
+
+	backoff := capDuration(time.Duration(math.Pow(2, float64(attempt)))*500*time.Millisecond, maxBackoff)
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
+
+func capDuration(d, max time.Duration) time.Duration {
+	if d > max {
+		return max
+	}
+	return d
+}
+
+// parseRetryAfter accepts both forms RFC 9110 allows: a number of seconds,
+// or an HTTP-date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t), true
+	}
+	return 0, false
+}
+
+// parseRateLimitReset reads OpenAI's x-ratelimit-reset-requests /
+// -tokens headers, which are either a duration string (e.g. "1s", "6m0s")
+// or a bare number of seconds.
+func parseRateLimitReset(h http.Header) (time.Duration, bool) {
+	for _, key := range []string{"x-ratelimit-reset-requests", "x-ratelimit-reset-tokens"} {
+		v := h.Get(key)
+		if v == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(v); err == nil {
+			return d, true
+		}
+		if secs, err := strconv.ParseFloat(v, 64); err == nil {
+			return time.Duration(secs * float64(time.Second)), true
+		}
+	}
+	return 0, false
+}
+
+// tokenBucket is a simple per-host rate limiter derived from a provider's
+// x-ratelimit-limit-requests header, refilling to capacity once per minute
+// (OpenAI-compatible rate-limit windows are expressed per minute).
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	refill   float64 // tokens per second
+	last     time.Time
+}
+
+func (b *tokenBucket) wait(ctx context.Context) {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = math.Min(b.capacity, b.tokens+now.Sub(b.last).Seconds()*b.refill)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		b.mu.Unlock()
+		return
+	}
+
+	deficit := 1 - b.tokens
+	b.tokens = 0
+	b.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Duration(deficit / b.refill * float64(time.Second))):
+	}
+}
+
+func (d *remoteHTTPDoer) limiter(host string) *tokenBucket {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.limiters[host]
+}
+
+func (d *remoteHTTPDoer) updateLimiter(host string, h http.Header) {
+	limit, err := strconv.ParseFloat(h.Get("x-ratelimit-limit-requests"), 64)
+	if err != nil || limit <= 0 {
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.limiters[host]; !ok {
+		d.limiters[host] = &tokenBucket{tokens: limit, capacity: limit, refill: limit / 60, last: time.Now()}
+	}
+}
+
+// circuitBreakerState is the state of a single host's circuitBreaker.
+type circuitBreakerState int
+
+const (
+	circuitClosed circuitBreakerState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreaker trips after threshold consecutive failures and half-opens
+// once cooldown has elapsed. While half-open it lets requests through; the
+// next recorded failure re-opens it and the next success closes it.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     circuitBreakerState
+	failures  int
+	openedAt  time.Time
+	threshold int
+	cooldown  time.Duration
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitOpen {
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = circuitHalfOpen
+	}
+
+	return true
+}
+
+func (b *circuitBreaker) recordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures = 0
+	b.state = circuitClosed
+}
+
+func (b *circuitBreaker) recordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures++
+	if b.state == circuitHalfOpen || b.failures >= b.threshold {
+		b.state = circuitOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (d *remoteHTTPDoer) breaker(host string) *circuitBreaker {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	b, ok := d.breakers[host]
+	if !ok {
+		b = &circuitBreaker{threshold: circuitBreakerThreshold, cooldown: circuitBreakerCooldown}
+		d.breakers[host] = b
+	}
+	return b
+}
+
+func envInt(key string, def int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return def
+}
+
+func envDuration(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return def
+}
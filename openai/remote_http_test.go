@@ -0,0 +1,88 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestNewRemoteHTTPClientSharesDoer guards against the doer being rebuilt on
+// every call, which would silently drop all breaker/limiter state between
+// requests.
+func TestNewRemoteHTTPClientSharesDoer(t *testing.T) {
+	a := NewRemoteHTTPClient(0).Transport.(*remoteHTTPDoer)
+	b := NewRemoteHTTPClient(0).Transport.(*remoteHTTPDoer)
+	if a != b {
+		t.Fatal("expected NewRemoteHTTPClient to reuse the same doer across calls")
+	}
+}
+
+// TestNewRemoteClientSharesDoer covers the same requirement for
+// NewRemoteClient's default (nil Transport) path, and that it's the same
+// singleton NewRemoteHTTPClient hands out.
+func TestNewRemoteClientSharesDoer(t *testing.T) {
+	a := NewRemoteClient(nil, "", nil).http.Transport.(*remoteHTTPDoer)
+	b := NewRemoteClient(nil, "", &http.Client{}).http.Transport.(*remoteHTTPDoer)
+	c := NewRemoteHTTPClient(0).Transport.(*remoteHTTPDoer)
+	if a != b || b != c {
+		t.Fatal("expected NewRemoteClient to reuse the same process-wide doer")
+	}
+}
+
+// TestNewRemoteClientWrapsCustomTransport verifies that a caller-supplied
+// custom Transport still gets wrapped with resilience behavior, but doesn't
+// share state with the process-wide singleton.
+func TestNewRemoteClientWrapsCustomTransport(t *testing.T) {
+	custom := http.DefaultTransport
+	client := NewRemoteClient(nil, "", &http.Client{Transport: custom})
+	doer, ok := client.http.Transport.(*remoteHTTPDoer)
+	if !ok {
+		t.Fatal("expected Transport to be wrapped in a remoteHTTPDoer")
+	}
+	if doer.next != custom {
+		t.Fatal("expected the custom Transport to be preserved as doer.next")
+	}
+	if doer == remoteHTTPDoerFor(nil) {
+		t.Fatal("expected a custom Transport to get its own doer, not the shared singleton")
+	}
+}
+
+// TestCircuitBreakerTripsAfterThreshold exercises the breaker in isolation:
+// it should allow requests until threshold consecutive failures, then block
+// until cooldown elapses.
+func TestCircuitBreakerTripsAfterThreshold(t *testing.T) {
+	b := &circuitBreaker{threshold: 2, cooldown: time.Hour}
+
+	if !b.allow() {
+		t.Fatal("expected breaker to allow requests before any failures")
+	}
+	b.recordFailure()
+	if !b.allow() {
+		t.Fatal("expected breaker to still allow below threshold")
+	}
+	b.recordFailure()
+	if b.allow() {
+		t.Fatal("expected breaker to trip open once threshold failures are recorded")
+	}
+
+	b.recordSuccess()
+	if !b.allow() {
+		t.Fatal("expected a recorded success to reset the breaker")
+	}
+}
+
+// TestTokenBucketExhaustsCapacity verifies a single available token is
+// consumed by wait rather than handed out repeatedly.
+func TestTokenBucketExhaustsCapacity(t *testing.T) {
+	b := &tokenBucket{tokens: 1, capacity: 1, refill: 0, last: time.Now()}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	b.wait(ctx)
+
+	if b.tokens >= 1 {
+		t.Fatalf("expected the single token to be consumed, got %v", b.tokens)
+	}
+}
@@ -0,0 +1,348 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+// anthropicProvider adapts requests to Anthropic's Messages API
+// (https://docs.anthropic.com/en/api/messages), including input_schema
+// tools and thinking blocks.
+type anthropicProvider struct{}
+
+func (anthropicProvider) Name() string { return "anthropic" }
+
+func (anthropicProvider) Detect(base *url.URL) bool {
+	return strings.Contains(base.Host, "anthropic.com")
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content any    `json:"content"`
+}
+
+type anthropicTool struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema any    `json:"input_schema"`
+}
+
+type anthropicThinking struct {
+	Type         string `json:"type"`
+	BudgetTokens int    `json:"budget_tokens,omitempty"`
+}
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	Messages    []anthropicMessage `json:"messages"`
+	System      string             `json:"system,omitempty"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature *float64           `json:"temperature,omitempty"`
+	TopP        *float64           `json:"top_p,omitempty"`
+	Tools       []anthropicTool    `json:"tools,omitempty"`
+	Stream      bool               `json:"stream,omitempty"`
+	Thinking    *anthropicThinking `json:"thinking,omitempty"`
+}
+
+type anthropicContentBlock struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	Thinking string          `json:"thinking,omitempty"`
+	ID       string          `json:"id,omitempty"`
+	Name     string          `json:"name,omitempty"`
+	Input    json.RawMessage `json:"input,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content    []anthropicContentBlock `json:"content"`
+	StopReason string                  `json:"stop_reason"`
+	Usage      struct {
+		InputTokens  int `json:"input_tokens"`
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+// anthropicStreamEvent covers the subset of Messages API SSE event payloads
+// needed to reassemble text, thinking, and tool-use content blocks.
+type anthropicStreamEvent struct {
+	Type         string                `json:"type"`
+	ContentBlock anthropicContentBlock `json:"content_block"`
+	Delta        struct {
+		Type        string `json:"type"`
+		Text        string `json:"text"`
+		Thinking    string `json:"thinking"`
+		PartialJSON string `json:"partial_json"`
+		StopReason  string `json:"stop_reason"`
+	} `json:"delta"`
+	Usage struct {
+		OutputTokens int `json:"output_tokens"`
+	} `json:"usage"`
+}
+
+func (anthropicProvider) Chat(ctx context.Context, base *url.URL, apiKey string, req *api.ChatRequest, fn func(api.ChatResponse) error) error {
+	anthropicReq := convertToAnthropicRequest(req)
+
+	requestURL := *base
+	requestURL.Path = "/v1/messages"
+
+	body, err := json.Marshal(anthropicReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("anthropic-version", "2023-06-01")
+	if apiKey != "" {
+		httpReq.Header.Set("x-api-key", apiKey)
+	}
+
+	resp, err := newHTTPClient().Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(b))
+	}
+
+	if anthropicReq.Stream {
+		return handleAnthropicStream(resp.Body, req.Model, fn)
+	}
+
+	var anthropicResp anthropicResponse
+	if err := json.NewDecoder(resp.Body).Decode(&anthropicResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return fn(convertFromAnthropicResponse(&anthropicResp, req.Model))
+}
+
+// Embed returns an error: Anthropic does not expose a native embeddings API.
+func (anthropicProvider) Embed(ctx context.Context, base *url.URL, apiKey string, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+	return nil, fmt.Errorf("anthropic: embeddings are not supported by this provider")
+}
+
+func convertToAnthropicRequest(req *api.ChatRequest) *anthropicRequest {
+	var system strings.Builder
+	var messages []anthropicMessage
+
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			if system.Len() > 0 {
+				system.WriteString("\n\n")
+			}
+			system.WriteString(msg.Content)
+			continue
+		}
+
+		amsg := anthropicMessage{Role: msg.Role}
+		switch {
+		case msg.Role == "tool":
+			amsg.Role = "user"
+			amsg.Content = []map[string]any{{
+				"type":        "tool_result",
+				"tool_use_id": msg.ToolCallID,
+				"content":     msg.Content,
+			}}
+		case len(msg.ToolCalls) > 0:
+			var blocks []map[string]any
+			if msg.Content != "" {
+				blocks = append(blocks, map[string]any{"type": "text", "text": msg.Content})
+			}
+			for _, tc := range msg.ToolCalls {
+				blocks = append(blocks, map[string]any{
+					"type":  "tool_use",
+					"id":    tc.ID,
+					"name":  tc.Function.Name,
+					"input": tc.Function.Arguments,
+				})
+			}
+			amsg.Content = blocks
+		default:
+			amsg.Content = msg.Content
+		}
+
+		messages = append(messages, amsg)
+	}
+
+	var tools []anthropicTool
+	for _, t := range req.Tools {
+		tools = append(tools, anthropicTool{
+			Name:        t.Function.Name,
+			Description: t.Function.Description,
+			InputSchema: t.Function.Parameters,
+		})
+	}
+
+	maxTokens := 4096
+	var temperature, topP *float64
+	if req.Options != nil {
+		if np, ok := req.Options["num_predict"].(int); ok && np > 0 {
+			maxTokens = np
+		}
+		if temp, ok := req.Options["temperature"].(float64); ok {
+			temperature = &temp
+		}
+		if tp, ok := req.Options["top_p"].(float64); ok {
+			topP = &tp
+		}
+	}
+
+	var thinking *anthropicThinking
+	if req.Think != nil {
+		thinking = &anthropicThinking{Type: "enabled", BudgetTokens: maxTokens / 2}
+	}
+
+	return &anthropicRequest{
+		Model:       req.Model,
+		Messages:    messages,
+		System:      system.String(),
+		MaxTokens:   maxTokens,
+		Temperature: temperature,
+		TopP:        topP,
+		Tools:       tools,
+		Stream:      req.Stream != nil && *req.Stream,
+		Thinking:    thinking,
+	}
+}
+
+func convertFromAnthropicResponse(resp *anthropicResponse, model string) api.ChatResponse {
+	msg := api.Message{Role: "assistant"}
+	var text strings.Builder
+
+	for _, block := range resp.Content {
+		switch block.Type {
+		case "text":
+			text.WriteString(block.Text)
+		case "thinking":
+			msg.Thinking += block.Thinking
+		case "tool_use":
+			var args api.ToolCallFunctionArguments
+			if len(block.Input) > 0 {
+				json.Unmarshal(block.Input, &args)
+			}
+			msg.ToolCalls = append(msg.ToolCalls, api.ToolCall{
+				ID: block.ID,
+				Function: api.ToolCallFunction{
+					Name:      block.Name,
+					Arguments: args,
+				},
+			})
+		}
+	}
+	msg.Content = text.String()
+
+	return api.ChatResponse{
+		Model:      model,
+		CreatedAt:  time.Now(),
+		Message:    msg,
+		Done:       true,
+		DoneReason: resp.StopReason,
+		Metrics: api.Metrics{
+			PromptEvalCount: resp.Usage.InputTokens,
+			EvalCount:       resp.Usage.OutputTokens,
+		},
+	}
+}
+
+// handleAnthropicStream reassembles a Messages API SSE stream into the same
+// per-chunk callback pattern used by the other providers: text and thinking
+// deltas are forwarded as they arrive, while tool_use input_json_delta
+// fragments are buffered and only attached to a tool call once its
+// content_block_stop event confirms the JSON is complete.
+func handleAnthropicStream(body io.Reader, model string, fn func(api.ChatResponse) error) error {
+	scanner := bufio.NewScanner(body)
+	var toolCalls []api.ToolCall
+	var argBuf strings.Builder
+	activeToolIndex := -1
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			continue
+		}
+
+		switch event.Type {
+		case "content_block_start":
+			if event.ContentBlock.Type == "tool_use" {
+				toolCalls = append(toolCalls, api.ToolCall{
+					ID:       event.ContentBlock.ID,
+					Function: api.ToolCallFunction{Name: event.ContentBlock.Name},
+				})
+				activeToolIndex = len(toolCalls) - 1
+				argBuf.Reset()
+			}
+		case "content_block_delta":
+			switch event.Delta.Type {
+			case "text_delta":
+				if err := fn(api.ChatResponse{
+					Model:     model,
+					CreatedAt: time.Now(),
+					Message:   api.Message{Role: "assistant", Content: event.Delta.Text},
+					Done:      false,
+				}); err != nil {
+					return err
+				}
+			case "thinking_delta":
+				if err := fn(api.ChatResponse{
+					Model:     model,
+					CreatedAt: time.Now(),
+					Message:   api.Message{Role: "assistant", Thinking: event.Delta.Thinking},
+					Done:      false,
+				}); err != nil {
+					return err
+				}
+			case "input_json_delta":
+				argBuf.WriteString(event.Delta.PartialJSON)
+			}
+		case "content_block_stop":
+			if activeToolIndex >= 0 {
+				var args api.ToolCallFunctionArguments
+				if argBuf.Len() > 0 {
+					json.Unmarshal([]byte(argBuf.String()), &args)
+				}
+				toolCalls[activeToolIndex].Function.Arguments = args
+				activeToolIndex = -1
+			}
+		case "message_delta":
+			if event.Delta.StopReason != "" {
+				return fn(api.ChatResponse{
+					Model:      model,
+					CreatedAt:  time.Now(),
+					Message:    api.Message{Role: "assistant", ToolCalls: toolCalls},
+					Done:       true,
+					DoneReason: event.Delta.StopReason,
+					Metrics:    api.Metrics{EvalCount: event.Usage.OutputTokens},
+				})
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package remote
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/ollama/ollama/api"
+)
+
+func TestAnthropicDetect(t *testing.T) {
+	cases := []struct {
+		host string
+		want bool
+	}{
+		{"api.anthropic.com", true},
+		{"generativelanguage.googleapis.com", false},
+		{"bedrock-runtime.us-east-1.amazonaws.com", false},
+	}
+	for _, c := range cases {
+		got := anthropicProvider{}.Detect(&url.URL{Host: c.host})
+		if got != c.want {
+			t.Errorf("Detect(%q) = %v, want %v", c.host, got, c.want)
+		}
+	}
+}
+
+func TestConvertToAnthropicRequestMergesSystemMessages(t *testing.T) {
+	req := &api.ChatRequest{
+		Messages: []api.Message{
+			{Role: "system", Content: "be helpful"},
+			{Role: "system", Content: "be concise"},
+			{Role: "user", Content: "hi"},
+		},
+	}
+
+	anthropicReq := convertToAnthropicRequest(req)
+
+	if anthropicReq.System != "be helpful\n\nbe concise" {
+		t.Fatalf("unexpected merged system prompt: %q", anthropicReq.System)
+	}
+	if len(anthropicReq.Messages) != 1 || anthropicReq.Messages[0].Content != "hi" {
+		t.Fatalf("unexpected messages: %+v", anthropicReq.Messages)
+	}
+}
+
+func TestConvertToAnthropicRequestToolCallAndResult(t *testing.T) {
+	req := &api.ChatRequest{
+		Messages: []api.Message{
+			{
+				Role: "assistant",
+				ToolCalls: []api.ToolCall{
+					{ID: "tu_1", Function: api.ToolCallFunction{Name: "get_weather", Arguments: api.ToolCallFunctionArguments{"location": "SF"}}},
+				},
+			},
+			{Role: "tool", ToolCallID: "tu_1", Content: "sunny"},
+		},
+	}
+
+	anthropicReq := convertToAnthropicRequest(req)
+	if len(anthropicReq.Messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(anthropicReq.Messages))
+	}
+
+	blocks, ok := anthropicReq.Messages[0].Content.([]map[string]any)
+	if !ok || len(blocks) != 1 || blocks[0]["type"] != "tool_use" || blocks[0]["id"] != "tu_1" {
+		t.Fatalf("unexpected tool_use block: %+v", anthropicReq.Messages[0].Content)
+	}
+
+	toolResultMsg := anthropicReq.Messages[1]
+	if toolResultMsg.Role != "user" {
+		t.Fatalf("expected tool result role to be remapped to user, got %q", toolResultMsg.Role)
+	}
+	resultBlocks, ok := toolResultMsg.Content.([]map[string]any)
+	if !ok || len(resultBlocks) != 1 || resultBlocks[0]["tool_use_id"] != "tu_1" {
+		t.Fatalf("unexpected tool_result block: %+v", toolResultMsg.Content)
+	}
+}
+
+func TestConvertFromAnthropicResponse(t *testing.T) {
+	resp := &anthropicResponse{
+		StopReason: "end_turn",
+		Content: []anthropicContentBlock{
+			{Type: "thinking", Thinking: "let me think"},
+			{Type: "text", Text: "hello"},
+			{Type: "tool_use", ID: "tu_1", Name: "get_weather", Input: json.RawMessage(`{"location":"SF"}`)},
+		},
+	}
+	resp.Usage.InputTokens = 10
+	resp.Usage.OutputTokens = 5
+
+	chatResp := convertFromAnthropicResponse(resp, "claude-3")
+
+	if chatResp.Message.Thinking != "let me think" {
+		t.Fatalf("unexpected thinking: %q", chatResp.Message.Thinking)
+	}
+	if chatResp.Message.Content != "hello" {
+		t.Fatalf("unexpected content: %q", chatResp.Message.Content)
+	}
+	if len(chatResp.Message.ToolCalls) != 1 || chatResp.Message.ToolCalls[0].Function.Arguments["location"] != "SF" {
+		t.Fatalf("unexpected tool calls: %+v", chatResp.Message.ToolCalls)
+	}
+	if chatResp.DoneReason != "end_turn" {
+		t.Fatalf("unexpected done reason: %q", chatResp.DoneReason)
+	}
+	if chatResp.Metrics.PromptEvalCount != 10 || chatResp.Metrics.EvalCount != 5 {
+		t.Fatalf("unexpected metrics: %+v", chatResp.Metrics)
+	}
+}
@@ -0,0 +1,354 @@
+package remote
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+// bedrockProvider adapts requests to Amazon Bedrock's Converse API
+// (https://docs.aws.amazon.com/bedrock/latest/APIReference/API_runtime_Converse.html),
+// signing each request with AWS SigV4.
+type bedrockProvider struct{}
+
+func (bedrockProvider) Name() string { return "bedrock" }
+
+func (bedrockProvider) Detect(base *url.URL) bool {
+	return strings.Contains(base.Host, "bedrock-runtime") && strings.Contains(base.Host, "amazonaws.com")
+}
+
+type bedrockToolUse struct {
+	ToolUseID string         `json:"toolUseId"`
+	Name      string         `json:"name"`
+	Input     map[string]any `json:"input"`
+}
+
+type bedrockToolResult struct {
+	ToolUseID string                `json:"toolUseId"`
+	Content   []bedrockContentBlock `json:"content"`
+}
+
+type bedrockContentBlock struct {
+	Text       string             `json:"text,omitempty"`
+	ToolUse    *bedrockToolUse    `json:"toolUse,omitempty"`
+	ToolResult *bedrockToolResult `json:"toolResult,omitempty"`
+}
+
+type bedrockMessage struct {
+	Role    string                `json:"role"`
+	Content []bedrockContentBlock `json:"content"`
+}
+
+type bedrockToolSpec struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	InputSchema struct {
+		JSON any `json:"json"`
+	} `json:"inputSchema"`
+}
+
+type bedrockToolConfig struct {
+	Tools []struct {
+		ToolSpec bedrockToolSpec `json:"toolSpec"`
+	} `json:"tools"`
+}
+
+type bedrockInferenceConfig struct {
+	Temperature *float64 `json:"temperature,omitempty"`
+	TopP        *float64 `json:"topP,omitempty"`
+	MaxTokens   *int     `json:"maxTokens,omitempty"`
+}
+
+type bedrockRequest struct {
+	Messages   []bedrockMessage `json:"messages"`
+	System     []struct {
+		Text string `json:"text"`
+	} `json:"system,omitempty"`
+	ToolConfig      *bedrockToolConfig      `json:"toolConfig,omitempty"`
+	InferenceConfig *bedrockInferenceConfig `json:"inferenceConfig,omitempty"`
+}
+
+type bedrockResponse struct {
+	Output struct {
+		Message bedrockMessage `json:"message"`
+	} `json:"output"`
+	StopReason string `json:"stopReason"`
+	Usage      struct {
+		InputTokens  int `json:"inputTokens"`
+		OutputTokens int `json:"outputTokens"`
+	} `json:"usage"`
+}
+
+func (bedrockProvider) Chat(ctx context.Context, base *url.URL, apiKey string, req *api.ChatRequest, fn func(api.ChatResponse) error) error {
+	bedrockReq := convertToBedrockRequest(req)
+
+	stream := req.Stream != nil && *req.Stream
+	action := "converse"
+	if stream {
+		action = "converse-stream"
+	}
+
+	requestURL := *base
+	requestURL.Path = fmt.Sprintf("/model/%s/%s", url.PathEscape(req.Model), action)
+
+	body, err := json.Marshal(bedrockReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	if err := signSigV4(httpReq, body, "bedrock"); err != nil {
+		return fmt.Errorf("failed to sign request: %w", err)
+	}
+
+	resp, err := newHTTPClient().Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(b))
+	}
+
+	if stream {
+		// converse-stream responses use the application/vnd.amazon.eventstream
+		// binary framing rather than SSE; decoding it is not yet implemented.
+		return fmt.Errorf("bedrock: streaming responses are not yet supported")
+	}
+
+	var bedrockResp bedrockResponse
+	if err := json.NewDecoder(resp.Body).Decode(&bedrockResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return fn(convertFromBedrockResponse(&bedrockResp, req.Model))
+}
+
+// Embed returns an error: Bedrock embedding models use a separate
+// InvokeModel body format per provider and aren't supported yet.
+func (bedrockProvider) Embed(ctx context.Context, base *url.URL, apiKey string, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+	return nil, fmt.Errorf("bedrock: embeddings are not yet supported by this provider")
+}
+
+func convertToBedrockRequest(req *api.ChatRequest) *bedrockRequest {
+	var system []struct {
+		Text string `json:"text"`
+	}
+	var messages []bedrockMessage
+
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			system = append(system, struct {
+				Text string `json:"text"`
+			}{Text: msg.Content})
+			continue
+		}
+
+		if msg.Role == "tool" {
+			messages = append(messages, bedrockMessage{
+				Role: "user",
+				Content: []bedrockContentBlock{{
+					ToolResult: &bedrockToolResult{
+						ToolUseID: msg.ToolCallID,
+						Content:   []bedrockContentBlock{{Text: msg.Content}},
+					},
+				}},
+			})
+			continue
+		}
+
+		var content []bedrockContentBlock
+		if msg.Content != "" {
+			content = append(content, bedrockContentBlock{Text: msg.Content})
+		}
+		for _, tc := range msg.ToolCalls {
+			content = append(content, bedrockContentBlock{
+				ToolUse: &bedrockToolUse{
+					ToolUseID: tc.ID,
+					Name:      tc.Function.Name,
+					Input:     map[string]any(tc.Function.Arguments),
+				},
+			})
+		}
+
+		messages = append(messages, bedrockMessage{Role: msg.Role, Content: content})
+	}
+
+	bedrockReq := &bedrockRequest{Messages: messages, System: system}
+
+	if len(req.Tools) > 0 {
+		tc := &bedrockToolConfig{}
+		for _, t := range req.Tools {
+			spec := bedrockToolSpec{Name: t.Function.Name, Description: t.Function.Description}
+			spec.InputSchema.JSON = t.Function.Parameters
+			tc.Tools = append(tc.Tools, struct {
+				ToolSpec bedrockToolSpec `json:"toolSpec"`
+			}{ToolSpec: spec})
+		}
+		bedrockReq.ToolConfig = tc
+	}
+
+	if req.Options != nil {
+		cfg := &bedrockInferenceConfig{}
+		if temp, ok := req.Options["temperature"].(float64); ok {
+			cfg.Temperature = &temp
+		}
+		if tp, ok := req.Options["top_p"].(float64); ok {
+			cfg.TopP = &tp
+		}
+		if np, ok := req.Options["num_predict"].(int); ok {
+			cfg.MaxTokens = &np
+		}
+		bedrockReq.InferenceConfig = cfg
+	}
+
+	return bedrockReq
+}
+
+func convertFromBedrockResponse(resp *bedrockResponse, model string) api.ChatResponse {
+	msg := api.Message{Role: "assistant"}
+	var text strings.Builder
+
+	for _, block := range resp.Output.Message.Content {
+		if block.Text != "" {
+			text.WriteString(block.Text)
+		}
+		if block.ToolUse != nil {
+			msg.ToolCalls = append(msg.ToolCalls, api.ToolCall{
+				ID: block.ToolUse.ToolUseID,
+				Function: api.ToolCallFunction{
+					Name:      block.ToolUse.Name,
+					Arguments: api.ToolCallFunctionArguments(block.ToolUse.Input),
+				},
+			})
+		}
+	}
+	msg.Content = text.String()
+
+	return api.ChatResponse{
+		Model:      model,
+		CreatedAt:  time.Now(),
+		Message:    msg,
+		Done:       true,
+		DoneReason: resp.StopReason,
+		Metrics: api.Metrics{
+			PromptEvalCount: resp.Usage.InputTokens,
+			EvalCount:       resp.Usage.OutputTokens,
+		},
+	}
+}
+
+// signSigV4 signs req in place using AWS Signature Version 4, reading
+// credentials from the standard AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY /
+// AWS_SESSION_TOKEN / AWS_REGION environment variables.
+func signSigV4(req *http.Request, body []byte, service string) error {
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	if accessKey == "" || secretKey == "" {
+		return fmt.Errorf("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY must be set")
+	}
+
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	dateStamp := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if sessionToken := os.Getenv("AWS_SESSION_TOKEN"); sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(req.Header)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	credentialScope := strings.Join([]string{dateStamp, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		credentialScope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := deriveSigningKey(secretKey, dateStamp, region, service)
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, credentialScope, signedHeaders, signature,
+	))
+
+	return nil
+}
+
+func canonicalizeHeaders(h http.Header) (signedHeaders, canonicalHeaders string) {
+	values := make(map[string]string, len(h))
+	names := make([]string, 0, len(h))
+	for name := range h {
+		lower := strings.ToLower(name)
+		names = append(names, lower)
+		values[lower] = strings.TrimSpace(h.Get(name))
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		sb.WriteString(name)
+		sb.WriteString(":")
+		sb.WriteString(values[name])
+		sb.WriteString("\n")
+	}
+
+	return strings.Join(names, ";"), sb.String()
+}
+
+func deriveSigningKey(secretKey, dateStamp, region, service string) []byte {
+	kDate := hmacSHA256([]byte("AWS4"+secretKey), dateStamp)
+	kRegion := hmacSHA256(kDate, region)
+	kService := hmacSHA256(kRegion, service)
+	return hmacSHA256(kService, "aws4_request")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
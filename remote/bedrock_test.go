@@ -0,0 +1,174 @@
+package remote
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ollama/ollama/api"
+)
+
+func TestBedrockDetect(t *testing.T) {
+	cases := []struct {
+		host string
+		want bool
+	}{
+		{"bedrock-runtime.us-east-1.amazonaws.com", true},
+		{"bedrock-runtime.eu-west-1.amazonaws.com", true},
+		{"api.anthropic.com", false},
+		{"generativelanguage.googleapis.com", false},
+	}
+	for _, c := range cases {
+		got := bedrockProvider{}.Detect(&url.URL{Host: c.host})
+		if got != c.want {
+			t.Errorf("Detect(%q) = %v, want %v", c.host, got, c.want)
+		}
+	}
+}
+
+func TestSignSigV4RequiresCredentials(t *testing.T) {
+	for _, key := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_REGION", "AWS_SESSION_TOKEN"} {
+		t.Setenv(key, "")
+	}
+
+	req, err := http.NewRequest("POST", "https://bedrock-runtime.us-east-1.amazonaws.com/model/x/converse", bytes.NewReader(nil))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	if err := signSigV4(req, nil, "bedrock"); err == nil {
+		t.Fatal("expected an error when AWS credentials are unset")
+	}
+}
+
+func TestSignSigV4SetsAuthorizationHeader(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-west-2")
+	t.Setenv("AWS_SESSION_TOKEN", "")
+
+	body := []byte(`{"messages":[]}`)
+	req, err := http.NewRequest("POST", "https://bedrock-runtime.us-west-2.amazonaws.com/model/x/converse", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	if err := signSigV4(req, body, "bedrock"); err != nil {
+		t.Fatalf("signSigV4 returned error: %v", err)
+	}
+
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		t.Fatalf("unexpected Authorization header: %q", auth)
+	}
+	if !strings.Contains(auth, "/us-west-2/bedrock/aws4_request") {
+		t.Fatalf("Authorization header missing credential scope: %q", auth)
+	}
+	if req.Header.Get("X-Amz-Date") == "" {
+		t.Fatal("expected X-Amz-Date to be set")
+	}
+	if req.Header.Get("X-Amz-Content-Sha256") == "" {
+		t.Fatal("expected X-Amz-Content-Sha256 to be set")
+	}
+}
+
+func TestSignSigV4IncludesSessionToken(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_SESSION_TOKEN", "a-session-token")
+
+	req, err := http.NewRequest("POST", "https://bedrock-runtime.us-east-1.amazonaws.com/model/x/converse", bytes.NewReader(nil))
+	if err != nil {
+		t.Fatalf("failed to build request: %v", err)
+	}
+
+	if err := signSigV4(req, nil, "bedrock"); err != nil {
+		t.Fatalf("signSigV4 returned error: %v", err)
+	}
+
+	if got := req.Header.Get("X-Amz-Security-Token"); got != "a-session-token" {
+		t.Fatalf("expected session token header, got %q", got)
+	}
+}
+
+func TestCanonicalizeHeadersSortsAndLowercases(t *testing.T) {
+	h := http.Header{}
+	h.Set("Content-Type", "application/json")
+	h.Set("Host", "bedrock-runtime.us-east-1.amazonaws.com")
+	h.Set("X-Amz-Date", "20240101T000000Z")
+
+	signedHeaders, canonicalHeaders := canonicalizeHeaders(h)
+
+	if signedHeaders != "content-type;host;x-amz-date" {
+		t.Fatalf("unexpected signed headers: %q", signedHeaders)
+	}
+	want := "content-type:application/json\nhost:bedrock-runtime.us-east-1.amazonaws.com\nx-amz-date:20240101T000000Z\n"
+	if canonicalHeaders != want {
+		t.Fatalf("unexpected canonical headers:\ngot:  %q\nwant: %q", canonicalHeaders, want)
+	}
+}
+
+func TestConvertToBedrockRequestToolCallAndResult(t *testing.T) {
+	req := &api.ChatRequest{
+		Messages: []api.Message{
+			{Role: "system", Content: "be helpful"},
+			{Role: "user", Content: "what's the weather?"},
+			{
+				Role: "assistant",
+				ToolCalls: []api.ToolCall{
+					{ID: "tu_1", Function: api.ToolCallFunction{Name: "get_weather", Arguments: api.ToolCallFunctionArguments{"location": "SF"}}},
+				},
+			},
+			{Role: "tool", ToolCallID: "tu_1", Content: "sunny"},
+		},
+	}
+
+	bedrockReq := convertToBedrockRequest(req)
+
+	if len(bedrockReq.System) != 1 || bedrockReq.System[0].Text != "be helpful" {
+		t.Fatalf("unexpected system messages: %+v", bedrockReq.System)
+	}
+	if len(bedrockReq.Messages) != 3 {
+		t.Fatalf("expected 3 messages, got %d", len(bedrockReq.Messages))
+	}
+
+	toolCallMsg := bedrockReq.Messages[1]
+	if toolCallMsg.Content[0].ToolUse == nil || toolCallMsg.Content[0].ToolUse.Name != "get_weather" {
+		t.Fatalf("expected a toolUse content block, got %+v", toolCallMsg.Content)
+	}
+
+	toolResultMsg := bedrockReq.Messages[2]
+	if toolResultMsg.Role != "user" || toolResultMsg.Content[0].ToolResult == nil || toolResultMsg.Content[0].ToolResult.ToolUseID != "tu_1" {
+		t.Fatalf("expected a toolResult content block, got %+v", toolResultMsg.Content)
+	}
+}
+
+func TestConvertFromBedrockResponse(t *testing.T) {
+	resp := &bedrockResponse{
+		StopReason: "end_turn",
+	}
+	resp.Output.Message.Content = []bedrockContentBlock{
+		{Text: "hello"},
+		{ToolUse: &bedrockToolUse{ToolUseID: "tu_1", Name: "get_weather", Input: map[string]any{"location": "SF"}}},
+	}
+	resp.Usage.InputTokens = 10
+	resp.Usage.OutputTokens = 5
+
+	chatResp := convertFromBedrockResponse(resp, "claude-3")
+
+	if chatResp.Message.Content != "hello" {
+		t.Fatalf("unexpected content: %q", chatResp.Message.Content)
+	}
+	if len(chatResp.Message.ToolCalls) != 1 || chatResp.Message.ToolCalls[0].Function.Arguments["location"] != "SF" {
+		t.Fatalf("unexpected tool calls: %+v", chatResp.Message.ToolCalls)
+	}
+	if chatResp.DoneReason != "end_turn" {
+		t.Fatalf("unexpected done reason: %q", chatResp.DoneReason)
+	}
+	if chatResp.Metrics.PromptEvalCount != 10 || chatResp.Metrics.EvalCount != 5 {
+		t.Fatalf("unexpected metrics: %+v", chatResp.Metrics)
+	}
+}
@@ -0,0 +1,339 @@
+package remote
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+// googleProvider adapts requests to Gemini's generateContent API
+// (https://ai.google.dev/api/generate-content), including
+// functionDeclarations tools.
+type googleProvider struct{}
+
+func (googleProvider) Name() string { return "google" }
+
+// Detect only matches the public Gemini API host. Vertex AI
+// (aiplatform.googleapis.com) uses a different request path and OAuth
+// bearer auth instead of an API-key query param, which Chat/Embed below
+// don't implement, so it's deliberately not detected here.
+func (googleProvider) Detect(base *url.URL) bool {
+	return strings.Contains(base.Host, "generativelanguage.googleapis.com")
+}
+
+type googleFuncCall struct {
+	Name string         `json:"name"`
+	Args map[string]any `json:"args"`
+}
+
+type googleFuncResp struct {
+	Name     string         `json:"name"`
+	Response map[string]any `json:"response"`
+}
+
+type googlePart struct {
+	Text             string          `json:"text,omitempty"`
+	FunctionCall     *googleFuncCall `json:"functionCall,omitempty"`
+	FunctionResponse *googleFuncResp `json:"functionResponse,omitempty"`
+}
+
+type googleContent struct {
+	Role  string       `json:"role,omitempty"`
+	Parts []googlePart `json:"parts"`
+}
+
+type googleFunctionDeclaration struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Parameters  any    `json:"parameters,omitempty"`
+}
+
+type googleTool struct {
+	FunctionDeclarations []googleFunctionDeclaration `json:"functionDeclarations"`
+}
+
+type googleGenerationConfig struct {
+	Temperature     *float64 `json:"temperature,omitempty"`
+	TopP            *float64 `json:"topP,omitempty"`
+	MaxOutputTokens *int     `json:"maxOutputTokens,omitempty"`
+}
+
+type googleRequest struct {
+	Contents          []googleContent         `json:"contents"`
+	SystemInstruction *googleContent          `json:"systemInstruction,omitempty"`
+	Tools             []googleTool            `json:"tools,omitempty"`
+	GenerationConfig  *googleGenerationConfig `json:"generationConfig,omitempty"`
+}
+
+type googleCandidate struct {
+	Content      googleContent `json:"content"`
+	FinishReason string        `json:"finishReason"`
+}
+
+type googleResponse struct {
+	Candidates    []googleCandidate `json:"candidates"`
+	UsageMetadata struct {
+		PromptTokenCount     int `json:"promptTokenCount"`
+		CandidatesTokenCount int `json:"candidatesTokenCount"`
+	} `json:"usageMetadata"`
+}
+
+func (googleProvider) Chat(ctx context.Context, base *url.URL, apiKey string, req *api.ChatRequest, fn func(api.ChatResponse) error) error {
+	googleReq := convertToGoogleRequest(req)
+
+	action := "generateContent"
+	stream := req.Stream != nil && *req.Stream
+	if stream {
+		action = "streamGenerateContent"
+	}
+
+	requestURL := *base
+	requestURL.Path = fmt.Sprintf("/v1beta/models/%s:%s", req.Model, action)
+	q := requestURL.Query()
+	q.Set("key", apiKey)
+	if stream {
+		q.Set("alt", "sse")
+	}
+	requestURL.RawQuery = q.Encode()
+
+	body, err := json.Marshal(googleReq)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := newHTTPClient().Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("API error (%d): %s", resp.StatusCode, string(b))
+	}
+
+	if stream {
+		return handleGoogleStream(resp.Body, req.Model, fn)
+	}
+
+	var googleResp googleResponse
+	if err := json.NewDecoder(resp.Body).Decode(&googleResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return fn(convertFromGoogleResponse(&googleResp, req.Model))
+}
+
+func (googleProvider) Embed(ctx context.Context, base *url.URL, apiKey string, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+	text, ok := req.Input.(string)
+	if !ok {
+		return nil, fmt.Errorf("google: batch embedding input is not yet supported")
+	}
+
+	requestURL := *base
+	requestURL.Path = fmt.Sprintf("/v1beta/models/%s:embedContent", req.Model)
+	q := requestURL.Query()
+	q.Set("key", apiKey)
+	requestURL.RawQuery = q.Encode()
+
+	body, err := json.Marshal(map[string]any{
+		"model":   "models/" + req.Model,
+		"content": googleContent{Parts: []googlePart{{Text: text}}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", requestURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := newHTTPClient().Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("API error (%d): %s", resp.StatusCode, string(b))
+	}
+
+	var embedResp struct {
+		Embedding struct {
+			Values []float32 `json:"values"`
+		} `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&embedResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	return &api.EmbedResponse{
+		Model:      req.Model,
+		Embeddings: [][]float32{embedResp.Embedding.Values},
+	}, nil
+}
+
+func convertToGoogleRequest(req *api.ChatRequest) *googleRequest {
+	var system *googleContent
+	var contents []googleContent
+
+	for _, msg := range req.Messages {
+		if msg.Role == "system" {
+			if system == nil {
+				system = &googleContent{}
+			}
+			system.Parts = append(system.Parts, googlePart{Text: msg.Content})
+			continue
+		}
+
+		role := "user"
+		if msg.Role == "assistant" {
+			role = "model"
+		}
+
+		content := googleContent{Role: role}
+		if msg.Role == "tool" {
+			content.Role = "user"
+			content.Parts = []googlePart{{
+				FunctionResponse: &googleFuncResp{
+					Name:     msg.ToolName,
+					Response: map[string]any{"content": msg.Content},
+				},
+			}}
+			contents = append(contents, content)
+			continue
+		}
+
+		if msg.Content != "" {
+			content.Parts = append(content.Parts, googlePart{Text: msg.Content})
+		}
+		for _, tc := range msg.ToolCalls {
+			content.Parts = append(content.Parts, googlePart{
+				FunctionCall: &googleFuncCall{Name: tc.Function.Name, Args: map[string]any(tc.Function.Arguments)},
+			})
+		}
+
+		contents = append(contents, content)
+	}
+
+	var tools []googleTool
+	if len(req.Tools) > 0 {
+		var decls []googleFunctionDeclaration
+		for _, t := range req.Tools {
+			decls = append(decls, googleFunctionDeclaration{
+				Name:        t.Function.Name,
+				Description: t.Function.Description,
+				Parameters:  t.Function.Parameters,
+			})
+		}
+		tools = []googleTool{{FunctionDeclarations: decls}}
+	}
+
+	var cfg *googleGenerationConfig
+	if req.Options != nil {
+		cfg = &googleGenerationConfig{}
+		if temp, ok := req.Options["temperature"].(float64); ok {
+			cfg.Temperature = &temp
+		}
+		if tp, ok := req.Options["top_p"].(float64); ok {
+			cfg.TopP = &tp
+		}
+		if np, ok := req.Options["num_predict"].(int); ok {
+			cfg.MaxOutputTokens = &np
+		}
+	}
+
+	return &googleRequest{
+		Contents:          contents,
+		SystemInstruction: system,
+		Tools:             tools,
+		GenerationConfig:  cfg,
+	}
+}
+
+func convertFromGoogleResponse(resp *googleResponse, model string) api.ChatResponse {
+	msg := api.Message{Role: "assistant"}
+	var text strings.Builder
+	var doneReason string
+
+	if len(resp.Candidates) > 0 {
+		cand := resp.Candidates[0]
+		doneReason = strings.ToLower(cand.FinishReason)
+		for _, part := range cand.Content.Parts {
+			if part.Text != "" {
+				text.WriteString(part.Text)
+			}
+			if part.FunctionCall != nil {
+				msg.ToolCalls = append(msg.ToolCalls, api.ToolCall{
+					Function: api.ToolCallFunction{
+						Name:      part.FunctionCall.Name,
+						Arguments: api.ToolCallFunctionArguments(part.FunctionCall.Args),
+					},
+				})
+			}
+		}
+	}
+	msg.Content = text.String()
+
+	return api.ChatResponse{
+		Model:      model,
+		CreatedAt:  time.Now(),
+		Message:    msg,
+		Done:       true,
+		DoneReason: doneReason,
+		Metrics: api.Metrics{
+			PromptEvalCount: resp.UsageMetadata.PromptTokenCount,
+			EvalCount:       resp.UsageMetadata.CandidatesTokenCount,
+		},
+	}
+}
+
+// handleGoogleStream decodes Gemini's SSE stream, where each "data:" line is
+// a complete response object carrying that chunk's new content. Each one is
+// converted and forwarded as it arrives, with Done set once a finishReason
+// appears.
+func handleGoogleStream(body io.Reader, model string, fn func(api.ChatResponse) error) error {
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data: ") {
+			continue
+		}
+		data := strings.TrimPrefix(line, "data: ")
+
+		var chunk googleResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+
+		resp := convertFromGoogleResponse(&chunk, model)
+		resp.Done = len(chunk.Candidates) > 0 && chunk.Candidates[0].FinishReason != ""
+		if err := fn(resp); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading stream: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package remote
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ollama/ollama/api"
+)
+
+func TestGoogleDetect(t *testing.T) {
+	cases := []struct {
+		host string
+		want bool
+	}{
+		{"generativelanguage.googleapis.com", true},
+		// Vertex AI uses a different request path and OAuth bearer auth,
+		// neither of which Chat/Embed implement, so it must not Detect.
+		{"aiplatform.googleapis.com", false},
+		{"api.anthropic.com", false},
+	}
+	for _, c := range cases {
+		got := googleProvider{}.Detect(&url.URL{Host: c.host})
+		if got != c.want {
+			t.Errorf("Detect(%q) = %v, want %v", c.host, got, c.want)
+		}
+	}
+}
+
+func TestConvertToGoogleRequestSystemAndToolCall(t *testing.T) {
+	req := &api.ChatRequest{
+		Messages: []api.Message{
+			{Role: "system", Content: "be helpful"},
+			{Role: "user", Content: "what's the weather?"},
+			{
+				Role: "assistant",
+				ToolCalls: []api.ToolCall{
+					{Function: api.ToolCallFunction{Name: "get_weather", Arguments: api.ToolCallFunctionArguments{"location": "SF"}}},
+				},
+			},
+			{Role: "tool", ToolName: "get_weather", Content: "sunny"},
+		},
+	}
+
+	googleReq := convertToGoogleRequest(req)
+
+	if googleReq.SystemInstruction == nil || len(googleReq.SystemInstruction.Parts) != 1 || googleReq.SystemInstruction.Parts[0].Text != "be helpful" {
+		t.Fatalf("unexpected system instruction: %+v", googleReq.SystemInstruction)
+	}
+	if len(googleReq.Contents) != 3 {
+		t.Fatalf("expected 3 contents, got %d", len(googleReq.Contents))
+	}
+
+	assistant := googleReq.Contents[1]
+	if assistant.Role != "model" || assistant.Parts[0].FunctionCall == nil || assistant.Parts[0].FunctionCall.Name != "get_weather" {
+		t.Fatalf("unexpected assistant content: %+v", assistant)
+	}
+
+	toolResult := googleReq.Contents[2]
+	if toolResult.Role != "user" || toolResult.Parts[0].FunctionResponse == nil || toolResult.Parts[0].FunctionResponse.Name != "get_weather" {
+		t.Fatalf("unexpected tool result content: %+v", toolResult)
+	}
+}
+
+func TestConvertFromGoogleResponse(t *testing.T) {
+	resp := &googleResponse{
+		Candidates: []googleCandidate{
+			{
+				FinishReason: "STOP",
+				Content: googleContent{
+					Parts: []googlePart{
+						{Text: "hello "},
+						{Text: "world"},
+						{FunctionCall: &googleFuncCall{Name: "get_weather", Args: map[string]any{"location": "SF"}}},
+					},
+				},
+			},
+		},
+	}
+	resp.UsageMetadata.PromptTokenCount = 10
+	resp.UsageMetadata.CandidatesTokenCount = 5
+
+	chatResp := convertFromGoogleResponse(resp, "gemini-pro")
+
+	if chatResp.Message.Content != "hello world" {
+		t.Fatalf("unexpected content: %q", chatResp.Message.Content)
+	}
+	if chatResp.DoneReason != "stop" {
+		t.Fatalf("unexpected done reason: %q", chatResp.DoneReason)
+	}
+	if len(chatResp.Message.ToolCalls) != 1 || chatResp.Message.ToolCalls[0].Function.Arguments["location"] != "SF" {
+		t.Fatalf("unexpected tool calls: %+v", chatResp.Message.ToolCalls)
+	}
+	if chatResp.Metrics.PromptEvalCount != 10 || chatResp.Metrics.EvalCount != 5 {
+		t.Fatalf("unexpected metrics: %+v", chatResp.Metrics)
+	}
+}
@@ -0,0 +1,34 @@
+package remote
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/openai"
+)
+
+// openaiProvider adapts requests to the OpenAI chat completions and
+// embeddings wire format by delegating to openai.RemoteClient. It is the
+// default fallback when no other provider's Detect matches.
+type openaiProvider struct{}
+
+func (openaiProvider) Name() string { return "openai" }
+
+// Detect matches any endpoint whose path is not under /api, since most
+// OpenAI-compatible services (Azure OpenAI, DashScope, vLLM, etc.) serve
+// /v1/chat/completions without any other distinguishing marker. It does not
+// look at the host, so it also matches the other providers' endpoints.
+func (openaiProvider) Detect(base *url.URL) bool {
+	path := strings.TrimSuffix(base.Path, "/")
+	return !strings.HasPrefix(path, "/api") && !strings.Contains(path, "/api/")
+}
+
+func (openaiProvider) Chat(ctx context.Context, base *url.URL, apiKey string, req *api.ChatRequest, fn func(api.ChatResponse) error) error {
+	return openai.NewRemoteClient(base, apiKey, newHTTPClient()).ChatCompletion(ctx, req, fn)
+}
+
+func (openaiProvider) Embed(ctx context.Context, base *url.URL, apiKey string, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+	return openai.NewRemoteClient(base, apiKey, newHTTPClient()).Embeddings(ctx, req)
+}
@@ -0,0 +1,91 @@
+// Package remote adapts Ollama's chat and embedding requests to the wire
+// format of various hosted LLM APIs, so a remote model can point at any
+// supported provider without that provider needing to ship an
+// OpenAI-compatible shim.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ollama/ollama/api"
+	"github.com/ollama/ollama/openai"
+)
+
+// defaultTimeout bounds a single remote call, matching the timeout
+// historically used for OpenAI-compatible forwarding.
+const defaultTimeout = 5 * time.Minute
+
+// Provider adapts chat and embedding requests to a specific remote LLM
+// API's wire format and converts its responses back to Ollama's types.
+type Provider interface {
+	// Name returns the provider's short identifier, e.g. "openai",
+	// "anthropic", "google", or "bedrock". It is also the value accepted by
+	// a remote model's explicit "provider=" config field.
+	Name() string
+
+	// Detect reports whether base looks like an endpoint this provider
+	// serves. It is only consulted when no explicit provider is configured.
+	Detect(base *url.URL) bool
+
+	// Chat sends a chat completion request, invoking fn once per streamed
+	// chunk (or once, for a non-streaming call) with the converted
+	// api.ChatResponse.
+	Chat(ctx context.Context, base *url.URL, apiKey string, req *api.ChatRequest, fn func(api.ChatResponse) error) error
+
+	// Embed sends an embeddings request and returns the converted
+	// api.EmbedResponse.
+	Embed(ctx context.Context, base *url.URL, apiKey string, req *api.EmbedRequest) (*api.EmbedResponse, error)
+}
+
+// openAIProvider is the default fallback used whenever no other registered
+// provider's Detect matches, since most OpenAI-compatible services don't
+// expose a distinguishing host or path.
+var openAIProvider Provider = openaiProvider{}
+
+// registry holds every non-default provider, checked in order by Resolve.
+var registry = []Provider{
+	anthropicProvider{},
+	googleProvider{},
+	bedrockProvider{},
+}
+
+// Resolve returns the Provider to use for base. providerName, taken from a
+// remote model's explicit "provider=" config field, always wins when set,
+// and it is an error for it not to name a registered provider - silently
+// falling back to host detection would route a misconfigured model to the
+// wrong provider instead of surfacing the typo. With no providerName, each
+// registered provider's Detect is tried in turn, falling back to the
+// OpenAI-compatible adapter.
+func Resolve(base *url.URL, providerName string) (Provider, error) {
+	if providerName != "" {
+		if providerName == openAIProvider.Name() {
+			return openAIProvider, nil
+		}
+		for _, p := range registry {
+			if p.Name() == providerName {
+				return p, nil
+			}
+		}
+		return nil, fmt.Errorf("remote: unknown provider %q", providerName)
+	}
+
+	for _, p := range registry {
+		if p.Detect(base) {
+			return p, nil
+		}
+	}
+
+	return openAIProvider, nil
+}
+
+// newHTTPClient returns the http.Client used by provider adapters for a
+// single request. It applies the same retry, rate-limit, and
+// circuit-breaker behavior as openai.RemoteClient, so every provider in the
+// registry gets the same resilience as the OpenAI-compatible path.
+func newHTTPClient() *http.Client {
+	return openai.NewRemoteHTTPClient(defaultTimeout)
+}
@@ -0,0 +1,74 @@
+package remote
+
+import (
+	"net/url"
+	"testing"
+)
+
+func mustURL(t *testing.T, raw string) *url.URL {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("failed to parse URL fixture: %v", err)
+	}
+	return u
+}
+
+func TestResolveExplicitProvider(t *testing.T) {
+	base := mustURL(t, "https://example.com")
+
+	p, err := Resolve(base, "anthropic")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "anthropic" {
+		t.Fatalf("expected anthropic, got %s", p.Name())
+	}
+}
+
+func TestResolveExplicitProviderOpenAI(t *testing.T) {
+	base := mustURL(t, "https://api.anthropic.com")
+
+	// An explicit "openai" should win even over a host that would
+	// otherwise Detect as a different provider.
+	p, err := Resolve(base, "openai")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "openai" {
+		t.Fatalf("expected openai, got %s", p.Name())
+	}
+}
+
+func TestResolveUnknownProviderIsAnError(t *testing.T) {
+	base := mustURL(t, "https://example.com")
+
+	_, err := Resolve(base, "not-a-real-provider")
+	if err == nil {
+		t.Fatal("expected an error for an unknown explicit provider, got nil")
+	}
+}
+
+func TestResolveDetectsFromHost(t *testing.T) {
+	base := mustURL(t, "https://api.anthropic.com")
+
+	p, err := Resolve(base, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "anthropic" {
+		t.Fatalf("expected anthropic, got %s", p.Name())
+	}
+}
+
+func TestResolveFallsBackToOpenAI(t *testing.T) {
+	base := mustURL(t, "https://my-openai-compatible-host.example.com")
+
+	p, err := Resolve(base, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "openai" {
+		t.Fatalf("expected openai fallback, got %s", p.Name())
+	}
+}
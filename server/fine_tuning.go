@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ollama/ollama/openai"
+)
+
+// Fine-tuning and file management aren't yet part of the pluggable
+// remote.Provider interface (remote/provider.go) since only OpenAI-compatible
+// hosts support them today. These dispatch directly through
+// openai.RemoteClient instead of remote.Resolve.
+//
+// OPEN SCOPE QUESTION, not yet resolved: these helpers are only the
+// forwarding layer. The /api/finetune/* HTTP endpoints and the "ollama
+// finetune" CLI subcommands that would call them live in the server's route
+// table and cmd/, neither of which exists in this package snapshot (only
+// openai/, remote/, and server/ are present). Before this lands for real,
+// someone needs to decide whether that wiring belongs in this change, a
+// follow-up, or isn't wanted at all - don't take the existence of this file
+// as evidence the feature is usable end to end.
+
+// callRemoteCreateFineTuningJob submits a new fine-tuning job to cfg's host.
+func callRemoteCreateFineTuningJob(ctx context.Context, cfg remoteModelConfig, req *openai.FineTuningJobRequest) (*openai.FineTuningJob, error) {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.CreateFineTuningJob(ctx, req)
+}
+
+// callRemoteRetrieveFineTuningJob fetches the current state of a fine-tuning job.
+func callRemoteRetrieveFineTuningJob(ctx context.Context, cfg remoteModelConfig, jobID string) (*openai.FineTuningJob, error) {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.RetrieveFineTuningJob(ctx, jobID)
+}
+
+// callRemoteCancelFineTuningJob cancels an in-progress fine-tuning job.
+func callRemoteCancelFineTuningJob(ctx context.Context, cfg remoteModelConfig, jobID string) (*openai.FineTuningJob, error) {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.CancelFineTuningJob(ctx, jobID)
+}
+
+// callRemoteListFineTuningJobs pages through cfg's fine-tuning jobs.
+func callRemoteListFineTuningJobs(ctx context.Context, cfg remoteModelConfig, after string, limit int) ([]openai.FineTuningJob, bool, error) {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return nil, false, err
+	}
+	return client.ListFineTuningJobs(ctx, after, limit)
+}
+
+// callRemoteListFineTuningJobEvents pages through a fine-tuning job's status/log events.
+func callRemoteListFineTuningJobEvents(ctx context.Context, cfg remoteModelConfig, jobID, after string, limit int) ([]openai.FineTuningJobEvent, bool, error) {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return nil, false, err
+	}
+	return client.ListFineTuningJobEvents(ctx, jobID, after, limit)
+}
+
+// callRemoteUploadFile uploads training or validation data to cfg's host.
+func callRemoteUploadFile(ctx context.Context, cfg remoteModelConfig, filename, purpose string, data []byte) (*openai.FileObject, error) {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.UploadFile(ctx, filename, purpose, data)
+}
+
+// callRemoteListFiles lists files previously uploaded to cfg's host.
+func callRemoteListFiles(ctx context.Context, cfg remoteModelConfig, purpose string) ([]openai.FileObject, error) {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return client.ListFiles(ctx, purpose)
+}
+
+// callRemoteDeleteFile deletes a previously uploaded file from cfg's host.
+func callRemoteDeleteFile(ctx context.Context, cfg remoteModelConfig, fileID string) error {
+	client, err := remoteFineTuningClient(cfg)
+	if err != nil {
+		return err
+	}
+	return client.DeleteFile(ctx, fileID)
+}
+
+func remoteFineTuningClient(cfg remoteModelConfig) (*openai.RemoteClient, error) {
+	base, err := url.Parse(cfg.Host)
+	if err != nil {
+		return nil, err
+	}
+	return openai.NewRemoteClient(base, cfg.APIKey, nil), nil
+}
@@ -15,38 +15,63 @@ import (
 
 	"github.com/ollama/ollama/api"
 	"github.com/ollama/ollama/openai"
+	"github.com/ollama/ollama/remote"
 )
 
-// isOpenAICompatible checks if a remote host URL is OpenAI-compatible
-// It checks if the URL contains common OpenAI-compatible paths
-func isOpenAICompatible(remoteHost string) bool {
-	// Check for common OpenAI-compatible endpoints
-	// Most OpenAI-compatible services use /v1/chat/completions
-	// This includes: OpenAI, Azure OpenAI, various Chinese providers (Alibaba DashScope, etc.)
-	u, err := url.Parse(remoteHost)
+// callOpenAICompatibleAPI forwards a chat request to the remote host
+// identified by providerName (an explicit "provider=" config value, or ""
+// to auto-detect from remoteHost). Non-OpenAI providers are handed off to
+// their remote.Provider adapter; everything else falls through to the
+// OpenAI-compatible conversion below.
+func callOpenAICompatibleAPI(ctx context.Context, remoteHost, remoteModel, apiKey, providerName string, req *api.ChatRequest, callback func(api.ChatResponse) error) error {
+	base, err := url.Parse(remoteHost)
 	if err != nil {
-		return false
+		return fmt.Errorf("invalid remote host URL: %w", err)
 	}
 
-	// Check if the path indicates it's an OpenAI-compatible endpoint
-	// or if it's a base URL without /api/ which suggests OpenAI format
-	path := strings.TrimSuffix(u.Path, "/")
-	return !strings.HasPrefix(path, "/api") && !strings.Contains(path, "/api/")
-}
+	provider, err := remote.Resolve(base, providerName)
+	if err != nil {
+		return err
+	}
+
+	// Serve deterministic requests from the response cache when one is
+	// configured, and tee the upstream response into it once the callback
+	// receives the fully assembled, done=true message. Hits are served
+	// uniformly across providers since the cache key is provider-agnostic.
+	// Streamed responses are only stored on the OpenAI-compatible path: the
+	// other adapters' streamed done=true message carries only the last chunk.
+	if cache := remoteCache(); cache != nil {
+		if cacheKey, ok := cacheableChatKey(req, remoteModel); ok {
+			if cached, hit := cache.Get(cacheKey); hit {
+				remoteCacheHits.Add(1)
+				return callback(*cached)
+			}
+			remoteCacheMisses.Add(1)
+
+			storable := req.Stream == nil || !*req.Stream || provider.Name() == "openai"
+			upstream := callback
+			callback = func(resp api.ChatResponse) error {
+				if resp.Done && storable {
+					cache.Put(cacheKey, &resp, defaultRemoteCacheTTL)
+				}
+				return upstream(resp)
+			}
+		}
+	}
+
+	if provider.Name() != "openai" {
+		providerReq := *req
+		providerReq.Model = remoteModel
+		return provider.Chat(ctx, base, apiKey, &providerReq, callback)
+	}
 
-// callOpenAICompatibleAPI forwards a chat request to an OpenAI-compatible endpoint
-func callOpenAICompatibleAPI(ctx context.Context, remoteHost, remoteModel, apiKey string, req *api.ChatRequest, callback func(api.ChatResponse) error) error {
 	// Convert api.ChatRequest to OpenAI format
 	openaiReq, err := convertToOpenAIChatRequest(req, remoteModel)
 	if err != nil {
 		return fmt.Errorf("failed to convert request: %w", err)
 	}
 
-	// Build the full URL
-	u, err := url.Parse(remoteHost)
-	if err != nil {
-		return fmt.Errorf("invalid remote host URL: %w", err)
-	}
+	u := *base
 
 	// Ensure the path ends with /v1/chat/completions
 	if !strings.HasSuffix(u.Path, "/v1/chat/completions") {
@@ -73,8 +98,9 @@ func callOpenAICompatibleAPI(ctx context.Context, remoteHost, remoteModel, apiKe
 		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
 	}
 
-	// Make the request
-	client := &http.Client{Timeout: 5 * time.Minute}
+	// Make the request. openai.NewRemoteHTTPClient applies the same retry,
+	// rate-limit, and circuit-breaker behavior as openai.RemoteClient.
+	client := openai.NewRemoteHTTPClient(5 * time.Minute)
 	resp, err := client.Do(httpReq)
 	if err != nil {
 		return fmt.Errorf("failed to make HTTP request: %w", err)
@@ -217,12 +243,47 @@ func convertToOpenAIChatRequest(req *api.ChatRequest, remoteModel string) (*open
 	}, nil
 }
 
+// openAIToolCallAccumulator buffers a single tool call's streamed
+// Function.Arguments fragments. OpenAI-compatible providers stream arguments
+// as raw JSON string pieces (e.g. `{"loc`, `ation":"S`, `F"}`) rather than
+// whole values, so the fragments must be concatenated before they can be
+// unmarshaled.
+type openAIToolCallAccumulator struct {
+	id        string
+	name      string
+	arguments strings.Builder
+}
+
+// finalizeOpenAIToolCalls unmarshals each accumulator's fully assembled
+// arguments string. It is only safe to call once the stream has signalled
+// completion (finish_reason "tool_calls" or the stream terminating).
+func finalizeOpenAIToolCalls(accs []*openAIToolCallAccumulator) []api.ToolCall {
+	var toolCalls []api.ToolCall
+	for _, acc := range accs {
+		var args api.ToolCallFunctionArguments
+		if acc.arguments.Len() > 0 {
+			if err := json.Unmarshal([]byte(acc.arguments.String()), &args); err != nil {
+				slog.Warn("failed to parse accumulated tool call arguments", "error", err)
+				continue
+			}
+		}
+		toolCalls = append(toolCalls, api.ToolCall{
+			ID: acc.id,
+			Function: api.ToolCallFunction{
+				Name:      acc.name,
+				Arguments: args,
+			},
+		})
+	}
+	return toolCalls
+}
+
 // handleOpenAIStreamingResponse processes a streaming response from OpenAI
 func handleOpenAIStreamingResponse(body io.Reader, callback func(api.ChatResponse) error) error {
 	scanner := bufio.NewScanner(body)
 	var fullContent strings.Builder
 	var fullThinking strings.Builder
-	var toolCalls []api.ToolCall
+	var toolCallAccs []*openAIToolCallAccumulator
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -248,7 +309,7 @@ func handleOpenAIStreamingResponse(body io.Reader, callback func(api.ChatRespons
 					Role:      "assistant",
 					Content:   fullContent.String(),
 					Thinking:  fullThinking.String(),
-					ToolCalls: toolCalls,
+					ToolCalls: finalizeOpenAIToolCalls(toolCallAccs),
 				},
 				Done:       true,
 				DoneReason: "stop",
@@ -308,43 +369,63 @@ func handleOpenAIStreamingResponse(body io.Reader, callback func(api.ChatRespons
 			}
 		}
 
-		// Handle tool calls
+		// Buffer tool-call argument fragments; they are only unmarshaled
+		// once the choice's finish_reason confirms the call is complete.
 		if len(choice.Delta.ToolCalls) > 0 {
 			for _, tc := range choice.Delta.ToolCalls {
-				// Check if this is a new tool call or an update to existing one
-				if tc.Index < len(toolCalls) {
-					// Update existing tool call
-					if tc.Function.Name != "" {
-						toolCalls[tc.Index].Function.Name = tc.Function.Name
-					}
-					if tc.Function.Arguments != "" {
-						var args api.ToolCallFunctionArguments
-						if err := json.Unmarshal([]byte(tc.Function.Arguments), &args); err == nil {
-							toolCalls[tc.Index].Function.Arguments = args
-						}
-					}
-				} else {
-					// New tool call
-					var args api.ToolCallFunctionArguments
-					if tc.Function.Arguments != "" {
-						json.Unmarshal([]byte(tc.Function.Arguments), &args)
-					}
-					toolCalls = append(toolCalls, api.ToolCall{
-						ID: tc.ID,
-						Function: api.ToolCallFunction{
-							Name:      tc.Function.Name,
-							Arguments: args,
-						},
-					})
+				for len(toolCallAccs) <= tc.Index {
+					toolCallAccs = append(toolCallAccs, &openAIToolCallAccumulator{})
 				}
+
+				acc := toolCallAccs[tc.Index]
+				if tc.ID != "" && acc.id == "" {
+					acc.id = tc.ID
+				}
+				if tc.Function.Name != "" && acc.name == "" {
+					acc.name = tc.Function.Name
+				}
+				acc.arguments.WriteString(tc.Function.Arguments)
 			}
 		}
+
+		if choice.FinishReason != nil && *choice.FinishReason == "tool_calls" {
+			return callback(api.ChatResponse{
+				Model:     chunk.Model,
+				CreatedAt: time.Now(),
+				Message: api.Message{
+					Role:      "assistant",
+					Content:   fullContent.String(),
+					Thinking:  fullThinking.String(),
+					ToolCalls: finalizeOpenAIToolCalls(toolCallAccs),
+				},
+				Done:       true,
+				DoneReason: *choice.FinishReason,
+			})
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
 		return fmt.Errorf("error reading stream: %w", err)
 	}
 
+	// Some providers end the stream (e.g. on early disconnect) without ever
+	// sending a finish_reason or a [DONE] marker. Flush whatever tool calls
+	// were buffered so a completed call is never silently dropped.
+	if len(toolCallAccs) > 0 {
+		return callback(api.ChatResponse{
+			Model:     "",
+			CreatedAt: time.Now(),
+			Message: api.Message{
+				Role:      "assistant",
+				Content:   fullContent.String(),
+				Thinking:  fullThinking.String(),
+				ToolCalls: finalizeOpenAIToolCalls(toolCallAccs),
+			},
+			Done:       true,
+			DoneReason: "stop",
+		})
+	}
+
 	return nil
 }
 
@@ -404,3 +485,60 @@ func handleOpenAINonStreamingResponse(body io.Reader, callback func(api.ChatResp
 		EvalCount:       completion.Usage.CompletionTokens,
 	})
 }
+
+// callOpenAICompatibleEmbed forwards an embedding request to the remote host
+// identified by providerName (an explicit "provider=" config value, or ""
+// to auto-detect from remoteHost), converting the response back to
+// Ollama's EmbedResponse format.
+func callOpenAICompatibleEmbed(ctx context.Context, remoteHost, remoteModel, apiKey, providerName string, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+	base, err := url.Parse(remoteHost)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote host URL: %w", err)
+	}
+
+	embedReq := *req
+	embedReq.Model = remoteModel
+
+	provider, err := remote.Resolve(base, providerName)
+	if err != nil {
+		return nil, err
+	}
+	if provider.Name() != "openai" {
+		return provider.Embed(ctx, base, apiKey, &embedReq)
+	}
+
+	slog.Debug("forwarding embed request to OpenAI-compatible endpoint", "url", base.String())
+	return openai.NewRemoteClient(base, apiKey, &http.Client{Timeout: 5 * time.Minute}).Embeddings(ctx, &embedReq)
+}
+
+// callOpenAICompatibleTranscribe forwards an audio transcription request to
+// an OpenAI-compatible endpoint and converts the response back to Ollama's
+// TranscribeResponse format.
+func callOpenAICompatibleTranscribe(ctx context.Context, remoteHost, remoteModel, apiKey string, req *api.TranscribeRequest) (*api.TranscribeResponse, error) {
+	base, err := url.Parse(remoteHost)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote host URL: %w", err)
+	}
+
+	slog.Debug("forwarding transcription request to OpenAI-compatible endpoint", "url", base.String())
+
+	transcribeReq := *req
+	transcribeReq.Model = remoteModel
+	return openai.NewRemoteClient(base, apiKey, &http.Client{Timeout: 5 * time.Minute}).Transcribe(ctx, &transcribeReq)
+}
+
+// callOpenAICompatibleImage forwards an image generation request to an
+// OpenAI-compatible endpoint and converts the response back to Ollama's
+// ImageResponse format.
+func callOpenAICompatibleImage(ctx context.Context, remoteHost, remoteModel, apiKey string, req *api.ImageRequest) (*api.ImageResponse, error) {
+	base, err := url.Parse(remoteHost)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote host URL: %w", err)
+	}
+
+	slog.Debug("forwarding image generation request to OpenAI-compatible endpoint", "url", base.String())
+
+	imageReq := *req
+	imageReq.Model = remoteModel
+	return openai.NewRemoteClient(base, apiKey, &http.Client{Timeout: 5 * time.Minute}).GenerateImage(ctx, &imageReq)
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ollama/ollama/api"
+)
+
+// TestHandleOpenAIStreamingResponseSplitFragments verifies that the server
+// forwarding path reassembles a tool call's Function.Arguments correctly
+// when an upstream OpenAI-compatible provider splits them mid-token across
+// several SSE chunks.
+func TestHandleOpenAIStreamingResponseSplitFragments(t *testing.T) {
+	body := strings.NewReader(strings.Join([]string{
+		`data: {"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "id": "call_1", "function": {"name": "get_weather", "arguments": "{\"loc"}}]}}]}`,
+		`data: {"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "ation\":\"S"}}]}}]}`,
+		`data: {"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "F\"}"}}]}, "finish_reason": "tool_calls"}]}`,
+		`data: [DONE]`,
+		"",
+	}, "\n\n"))
+
+	var responses []api.ChatResponse
+	err := handleOpenAIStreamingResponse(body, func(resp api.ChatResponse) error {
+		responses = append(responses, resp)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("handleOpenAIStreamingResponse returned error: %v", err)
+	}
+
+	last := responses[len(responses)-1]
+	if !last.Done {
+		t.Fatalf("expected final response to be done, got %+v", last)
+	}
+	if len(last.Message.ToolCalls) != 1 {
+		t.Fatalf("expected 1 tool call, got %d", len(last.Message.ToolCalls))
+	}
+
+	tc := last.Message.ToolCalls[0]
+	if tc.ID != "call_1" || tc.Function.Name != "get_weather" {
+		t.Fatalf("unexpected tool call identity: %+v", tc)
+	}
+	if got := tc.Function.Arguments["location"]; got != "SF" {
+		t.Fatalf("expected location %q, got %q", "SF", got)
+	}
+}
+
+// TestHandleOpenAIStreamingResponseEarlyDisconnect verifies that tool-call
+// fragments accumulated so far are still flushed when the stream ends
+// without a finish_reason or [DONE] marker.
+func TestHandleOpenAIStreamingResponseEarlyDisconnect(t *testing.T) {
+	body := strings.NewReader(strings.Join([]string{
+		`data: {"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "id": "call_1", "function": {"name": "get_weather", "arguments": "{\"loc"}}]}}]}`,
+		`data: {"model": "m", "created": 1, "choices": [{"index": 0, "delta": {"tool_calls": [{"index": 0, "function": {"arguments": "ation\":\"SF\"}"}}]}}]}`,
+		"",
+	}, "\n\n"))
+
+	var responses []api.ChatResponse
+	err := handleOpenAIStreamingResponse(body, func(resp api.ChatResponse) error {
+		responses = append(responses, resp)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("handleOpenAIStreamingResponse returned error: %v", err)
+	}
+
+	last := responses[len(responses)-1]
+	if !last.Done || len(last.Message.ToolCalls) != 1 {
+		t.Fatalf("expected buffered tool call to be flushed, got %+v", last)
+	}
+	if got := last.Message.ToolCalls[0].Function.Arguments["location"]; got != "SF" {
+		t.Fatalf("expected location %q, got %q", "SF", got)
+	}
+}
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+
+	"github.com/ollama/ollama/api"
+)
+
+// remoteModelConfig describes where a remote model's requests should be
+// routed. Provider is the explicit "provider=" field from the remote
+// model's config; when empty, the provider is detected from Host.
+type remoteModelConfig struct {
+	Host     string
+	Model    string
+	APIKey   string
+	Provider string
+}
+
+// callRemoteChat forwards a chat request for cfg, resolving to whichever
+// remote.Provider matches cfg.Provider (or is detected from cfg.Host) via
+// callOpenAICompatibleAPI, which also applies the response cache.
+func callRemoteChat(ctx context.Context, cfg remoteModelConfig, req *api.ChatRequest, fn func(api.ChatResponse) error) error {
+	return callOpenAICompatibleAPI(ctx, cfg.Host, cfg.Model, cfg.APIKey, cfg.Provider, req, fn)
+}
+
+// callRemoteEmbed forwards an embeddings request for cfg, resolving to
+// whichever remote.Provider matches cfg.Provider (or is detected from
+// cfg.Host) via callOpenAICompatibleEmbed.
+func callRemoteEmbed(ctx context.Context, cfg remoteModelConfig, req *api.EmbedRequest) (*api.EmbedResponse, error) {
+	return callOpenAICompatibleEmbed(ctx, cfg.Host, cfg.Model, cfg.APIKey, cfg.Provider, req)
+}
+
+// callRemoteTranscribe forwards a transcription request for cfg via
+// callOpenAICompatibleTranscribe. Unlike chat and embeddings, transcription
+// isn't part of the remote.Provider interface yet - only OpenAI-compatible
+// hosts support it today - so cfg.Provider is ignored and every request goes
+// through the OpenAI-compatible path.
+func callRemoteTranscribe(ctx context.Context, cfg remoteModelConfig, req *api.TranscribeRequest) (*api.TranscribeResponse, error) {
+	return callOpenAICompatibleTranscribe(ctx, cfg.Host, cfg.Model, cfg.APIKey, req)
+}
+
+// callRemoteImage forwards an image generation request for cfg via
+// callOpenAICompatibleImage. As with callRemoteTranscribe, image generation
+// isn't part of the remote.Provider interface yet, so cfg.Provider is
+// ignored and every request goes through the OpenAI-compatible path.
+func callRemoteImage(ctx context.Context, cfg remoteModelConfig, req *api.ImageRequest) (*api.ImageResponse, error) {
+	return callOpenAICompatibleImage(ctx, cfg.Host, cfg.Model, cfg.APIKey, req)
+}
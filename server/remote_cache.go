@@ -0,0 +1,241 @@
+package server
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ollama/ollama/api"
+)
+
+// remoteCacheMode selects the OLLAMA_REMOTE_CACHE backend: "off" (default),
+// "memory", or "disk".
+type remoteCacheMode string
+
+const (
+	remoteCacheOff    remoteCacheMode = "off"
+	remoteCacheMemory remoteCacheMode = "memory"
+	remoteCacheDisk   remoteCacheMode = "disk"
+
+	defaultRemoteCacheEntries = 1000
+	defaultRemoteCacheBytes   = 64 << 20 // 64MiB
+	defaultRemoteCacheTTL     = 10 * time.Minute
+)
+
+// remoteResponseCache stores completed api.ChatResponse values for
+// deterministic remote chat requests, keyed by cacheableChatKey.
+type remoteResponseCache interface {
+	Get(key string) (*api.ChatResponse, bool)
+	Put(key string, resp *api.ChatResponse, ttl time.Duration)
+}
+
+var (
+	remoteCacheOnce   sync.Once
+	activeRemoteCache remoteResponseCache
+	remoteCacheHits   atomic.Uint64
+	remoteCacheMisses atomic.Uint64
+)
+
+// RemoteCacheMetrics returns the cumulative hit/miss counts for the remote
+// response cache, for the metrics endpoint to expose as gauges.
+func RemoteCacheMetrics() (hits, misses uint64) {
+	return remoteCacheHits.Load(), remoteCacheMisses.Load()
+}
+
+// remoteCache returns the process-wide response cache configured by
+// OLLAMA_REMOTE_CACHE, initializing it on first use. A nil return means
+// caching is disabled.
+func remoteCache() remoteResponseCache {
+	remoteCacheOnce.Do(func() {
+		switch remoteCacheMode(os.Getenv("OLLAMA_REMOTE_CACHE")) {
+		case remoteCacheDisk:
+			home, err := os.UserHomeDir()
+			if err != nil {
+				slog.Warn("failed to resolve home directory for remote cache, disabling", "error", err)
+				return
+			}
+
+			path := filepath.Join(home, ".ollama", "remote-cache", "cache.db")
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				slog.Warn("failed to create remote cache directory, disabling", "error", err)
+				return
+			}
+
+			cache, err := newBoltRemoteCache(path)
+			if err != nil {
+				slog.Warn("failed to open disk remote cache, disabling", "error", err)
+				return
+			}
+			activeRemoteCache = cache
+		case remoteCacheMemory:
+			activeRemoteCache = newMemoryRemoteCache(defaultRemoteCacheEntries, defaultRemoteCacheBytes)
+		}
+	})
+	return activeRemoteCache
+}
+
+// canonicalChatPayload is the subset of a chat request that determines
+// whether two requests are equivalent for caching purposes.
+type canonicalChatPayload struct {
+	Model          string             `json:"model"`
+	Messages       []canonicalMessage `json:"messages"`
+	Tools          []api.Tool         `json:"tools,omitempty"`
+	ResponseFormat any                `json:"response_format,omitempty"`
+	Seed           any                `json:"seed,omitempty"`
+	Temperature    any                `json:"temperature,omitempty"`
+	TopP           any                `json:"top_p,omitempty"`
+	MaxTokens      any                `json:"max_tokens,omitempty"`
+}
+
+type canonicalMessage struct {
+	Role       string          `json:"role"`
+	Content    string          `json:"content"`
+	Thinking   string          `json:"thinking,omitempty"`
+	Images     []api.ImageData `json:"images,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+	ToolCalls  []api.ToolCall  `json:"tool_calls,omitempty"`
+}
+
+// cacheableChatKey returns the SHA-256 cache key for req if it's eligible
+// for caching. Only determinism is checked (temperature 0 or an explicit
+// seed): requests with tools are eligible like any other, with the tools
+// part of the key, and whether req streams does not affect eligibility.
+// encoding/json sorts map keys when marshaling, so the canonical payload's
+// JSON encoding is stable across equivalent requests.
+func cacheableChatKey(req *api.ChatRequest, remoteModel string) (string, bool) {
+	var seed any
+	deterministic := false
+	if req.Options != nil {
+		if s, ok := req.Options["seed"]; ok {
+			seed = s
+			deterministic = true
+		}
+		if temp, ok := req.Options["temperature"].(float64); ok && temp == 0 {
+			deterministic = true
+		}
+	}
+	if !deterministic {
+		return "", false
+	}
+
+	messages := make([]canonicalMessage, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = canonicalMessage{
+			Role:       m.Role,
+			Content:    m.Content,
+			Thinking:   m.Thinking,
+			Images:     m.Images,
+			ToolCallID: m.ToolCallID,
+			ToolCalls:  m.ToolCalls,
+		}
+	}
+
+	payload := canonicalChatPayload{
+		Model:          remoteModel,
+		Messages:       messages,
+		Tools:          req.Tools,
+		ResponseFormat: req.Format,
+		Seed:           seed,
+	}
+	if req.Options != nil {
+		payload.Temperature = req.Options["temperature"]
+		payload.TopP = req.Options["top_p"]
+		payload.MaxTokens = req.Options["num_predict"]
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", false
+	}
+
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), true
+}
+
+// remoteCacheEntry is a single memoryRemoteCache node.
+type remoteCacheEntry struct {
+	key       string
+	resp      api.ChatResponse
+	expiresAt time.Time
+	size      int
+}
+
+// memoryRemoteCache is a bounded in-memory LRU, evicting the
+// least-recently-used entry once either maxEntries or maxBytes is exceeded.
+type memoryRemoteCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	maxBytes   int
+	curBytes   int
+	ll         *list.List
+	items      map[string]*list.Element
+}
+
+func newMemoryRemoteCache(maxEntries, maxBytes int) *memoryRemoteCache {
+	return &memoryRemoteCache{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+	}
+}
+
+func (c *memoryRemoteCache) Get(key string) (*api.ChatResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*remoteCacheEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.removeElement(el)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+	resp := entry.resp
+	return &resp, true
+}
+
+func (c *memoryRemoteCache) Put(key string, resp *api.ChatResponse, ttl time.Duration) {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[key]; ok {
+		c.removeElement(el)
+	}
+
+	entry := &remoteCacheEntry{key: key, resp: *resp, expiresAt: time.Now().Add(ttl), size: len(body)}
+	c.items[key] = c.ll.PushFront(entry)
+	c.curBytes += entry.size
+
+	for c.ll.Len() > c.maxEntries || c.curBytes > c.maxBytes {
+		oldest := c.ll.Back()
+		if oldest == nil {
+			break
+		}
+		c.removeElement(oldest)
+	}
+}
+
+func (c *memoryRemoteCache) removeElement(el *list.Element) {
+	entry := el.Value.(*remoteCacheEntry)
+	c.ll.Remove(el)
+	delete(c.items, entry.key)
+	c.curBytes -= entry.size
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ollama/ollama/api"
+)
+
+var remoteCacheBucket = []byte("remote-cache")
+
+// boltRemoteCache persists cached chat responses to a BoltDB file under
+// ~/.ollama/remote-cache so they survive process restarts.
+type boltRemoteCache struct {
+	db       *bolt.DB
+	maxBytes int
+}
+
+type boltCacheEntry struct {
+	Response   api.ChatResponse `json:"response"`
+	ExpiresAt  time.Time        `json:"expires_at"`
+	AccessedAt time.Time        `json:"accessed_at"`
+}
+
+func newBoltRemoteCache(path string) (*boltRemoteCache, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt db: %w", err)
+	}
+
+	if err := db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(remoteCacheBucket)
+		return err
+	}); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create bucket: %w", err)
+	}
+
+	return &boltRemoteCache{db: db, maxBytes: defaultRemoteCacheBytes}, nil
+}
+
+// Get touches entry.AccessedAt on every hit so evictUntilFits can evict the
+// actual least-recently-used entry rather than just the oldest key.
+func (c *boltRemoteCache) Get(key string) (*api.ChatResponse, bool) {
+	var entry boltCacheEntry
+	found := false
+
+	_ = c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(remoteCacheBucket)
+		v := b.Get([]byte(key))
+		if v == nil {
+			return nil
+		}
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return nil
+		}
+		found = true
+
+		entry.AccessedAt = time.Now()
+		body, err := json.Marshal(entry)
+		if err != nil {
+			return nil
+		}
+		return b.Put([]byte(key), body)
+	})
+
+	if !found {
+		return nil, false
+	}
+	if time.Now().After(entry.ExpiresAt) {
+		c.delete(key)
+		return nil, false
+	}
+
+	return &entry.Response, true
+}
+
+func (c *boltRemoteCache) Put(key string, resp *api.ChatResponse, ttl time.Duration) {
+	now := time.Now()
+	body, err := json.Marshal(boltCacheEntry{Response: *resp, ExpiresAt: now.Add(ttl), AccessedAt: now})
+	if err != nil {
+		return
+	}
+
+	_ = c.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(remoteCacheBucket)
+		if err := evictUntilFits(b, c.maxBytes, len(body)); err != nil {
+			return err
+		}
+		return b.Put([]byte(key), body)
+	})
+}
+
+func (c *boltRemoteCache) delete(key string) {
+	_ = c.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(remoteCacheBucket).Delete([]byte(key))
+	})
+}
+
+// evictUntilFits removes the least-recently-accessed entries (by
+// boltCacheEntry.AccessedAt, bumped on every Get) until the bucket plus
+// incoming has room under maxBytes. Finding the oldest entry requires a full
+// bucket scan, same as the size scan above, which is fine at this cache's
+// scale but wouldn't be if maxBytes sized a much larger bucket.
+func evictUntilFits(b *bolt.Bucket, maxBytes, incoming int) error {
+	total := incoming
+	if err := b.ForEach(func(_, v []byte) error {
+		total += len(v)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for total > maxBytes {
+		k, size, ok := oldestEntry(b)
+		if !ok {
+			break
+		}
+		total -= size
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// oldestEntry returns the key and size of the entry with the smallest
+// AccessedAt in b, or ok=false if b is empty.
+func oldestEntry(b *bolt.Bucket) (key []byte, size int, ok bool) {
+	var oldestAt time.Time
+
+	_ = b.ForEach(func(k, v []byte) error {
+		var entry boltCacheEntry
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return nil
+		}
+		if !ok || entry.AccessedAt.Before(oldestAt) {
+			key = append([]byte(nil), k...)
+			size = len(v)
+			oldestAt = entry.AccessedAt
+			ok = true
+		}
+		return nil
+	})
+
+	return key, size, ok
+}
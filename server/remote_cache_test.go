@@ -0,0 +1,188 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/ollama/ollama/api"
+)
+
+func TestCacheableChatKeyRequiresDeterminism(t *testing.T) {
+	req := &api.ChatRequest{Model: "m", Messages: []api.Message{{Role: "user", Content: "hi"}}}
+
+	if _, ok := cacheableChatKey(req, "m"); ok {
+		t.Fatal("expected a request with no seed and no temperature=0 to be ineligible for caching")
+	}
+
+	req.Options = map[string]any{"seed": 1}
+	if _, ok := cacheableChatKey(req, "m"); !ok {
+		t.Fatal("expected a request with an explicit seed to be eligible for caching")
+	}
+}
+
+func TestCacheableChatKeyIsStableAcrossEquivalentRequests(t *testing.T) {
+	req := func() *api.ChatRequest {
+		return &api.ChatRequest{
+			Model:   "m",
+			Options: map[string]any{"seed": 1, "temperature": 0.0},
+			Messages: []api.Message{
+				{Role: "user", Content: "hi", Images: []api.ImageData{{1, 2, 3}}},
+			},
+		}
+	}
+
+	k1, ok1 := cacheableChatKey(req(), "remote-m")
+	k2, ok2 := cacheableChatKey(req(), "remote-m")
+	if !ok1 || !ok2 {
+		t.Fatal("expected both requests to be cacheable")
+	}
+	if k1 != k2 {
+		t.Fatalf("expected equivalent requests to produce the same key, got %q and %q", k1, k2)
+	}
+}
+
+func TestCacheableChatKeyDiffersWithToolCallIDOrImagesOrThinking(t *testing.T) {
+	base := &api.ChatRequest{
+		Model:    "m",
+		Options:  map[string]any{"seed": 1},
+		Messages: []api.Message{{Role: "user", Content: "hi"}},
+	}
+	baseKey, _ := cacheableChatKey(base, "m")
+
+	withToolCallID := &api.ChatRequest{
+		Model:    "m",
+		Options:  map[string]any{"seed": 1},
+		Messages: []api.Message{{Role: "user", Content: "hi", ToolCallID: "call_1"}},
+	}
+	if k, _ := cacheableChatKey(withToolCallID, "m"); k == baseKey {
+		t.Fatal("expected ToolCallID to change the cache key")
+	}
+
+	withImages := &api.ChatRequest{
+		Model:    "m",
+		Options:  map[string]any{"seed": 1},
+		Messages: []api.Message{{Role: "user", Content: "hi", Images: []api.ImageData{{1, 2, 3}}}},
+	}
+	if k, _ := cacheableChatKey(withImages, "m"); k == baseKey {
+		t.Fatal("expected Images to change the cache key")
+	}
+
+	withThinking := &api.ChatRequest{
+		Model:    "m",
+		Options:  map[string]any{"seed": 1},
+		Messages: []api.Message{{Role: "user", Content: "hi", Thinking: "hmm"}},
+	}
+	if k, _ := cacheableChatKey(withThinking, "m"); k == baseKey {
+		t.Fatal("expected Thinking to change the cache key")
+	}
+}
+
+func TestMemoryRemoteCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newMemoryRemoteCache(2, 1<<20)
+
+	c.Put("a", &api.ChatResponse{Model: "a"}, time.Minute)
+	c.Put("b", &api.ChatResponse{Model: "b"}, time.Minute)
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected a cache hit for \"a\"")
+	}
+
+	c.Put("c", &api.ChatResponse{Model: "c"}, time.Minute)
+
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("expected \"b\" to have been evicted as least recently used")
+	}
+	if _, ok := c.Get("a"); !ok {
+		t.Fatal("expected \"a\" to survive eviction since it was recently accessed")
+	}
+	if _, ok := c.Get("c"); !ok {
+		t.Fatal("expected \"c\" to still be cached")
+	}
+}
+
+func TestMemoryRemoteCacheExpiresEntries(t *testing.T) {
+	c := newMemoryRemoteCache(10, 1<<20)
+	c.Put("a", &api.ChatResponse{Model: "a"}, -time.Minute)
+
+	if _, ok := c.Get("a"); ok {
+		t.Fatal("expected an already-expired entry to be treated as a miss")
+	}
+}
+
+// boltBucketSize sums the byte size of every value currently stored in
+// cache's bucket, for pinning maxBytes to an exact eviction threshold.
+func boltBucketSize(t *testing.T, cache *boltRemoteCache) int {
+	t.Helper()
+	total := 0
+	if err := cache.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(remoteCacheBucket).ForEach(func(_, v []byte) error {
+			total += len(v)
+			return nil
+		})
+	}); err != nil {
+		t.Fatalf("failed to measure bucket size: %v", err)
+	}
+	return total
+}
+
+func TestBoltRemoteCacheGetPutRoundTrip(t *testing.T) {
+	cache, err := newBoltRemoteCache(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("failed to open bolt cache: %v", err)
+	}
+	defer cache.db.Close()
+
+	resp := &api.ChatResponse{Model: "m"}
+	cache.Put("k", resp, time.Minute)
+
+	got, ok := cache.Get("k")
+	if !ok {
+		t.Fatal("expected a cache hit")
+	}
+	if got.Model != "m" {
+		t.Fatalf("unexpected cached response: %+v", got)
+	}
+}
+
+func TestBoltRemoteCacheEvictsLeastRecentlyAccessed(t *testing.T) {
+	cache, err := newBoltRemoteCache(filepath.Join(t.TempDir(), "cache.db"))
+	if err != nil {
+		t.Fatalf("failed to open bolt cache: %v", err)
+	}
+	defer cache.db.Close()
+
+	resp := func(model string) *api.ChatResponse {
+		return &api.ChatResponse{Model: model, Message: api.Message{Content: "some response content"}}
+	}
+
+	// Start with room for everything, put "a" and "b", then measure what
+	// they actually take up so maxBytes can be pinned exactly - leaving no
+	// room for a third entry without an eviction.
+	cache.maxBytes = 1 << 20
+	cache.Put("a", resp("a"), time.Minute)
+	time.Sleep(time.Millisecond)
+	cache.Put("b", resp("b"), time.Minute)
+	// A little slack absorbs the few bytes a timestamp's fractional-second
+	// formatting can vary by, so only "b" - not also "a" - gets evicted
+	// below.
+	cache.maxBytes = boltBucketSize(t, cache) + 8
+
+	// Touch "a" so it's more recently accessed than "b".
+	time.Sleep(time.Millisecond)
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("expected a cache hit for \"a\"")
+	}
+
+	cache.Put("c", resp("c"), time.Minute)
+
+	if _, ok := cache.Get("b"); ok {
+		t.Fatal("expected \"b\" to have been evicted as least recently accessed")
+	}
+	if _, ok := cache.Get("c"); !ok {
+		t.Fatal("expected \"c\" to still be cached")
+	}
+}